@@ -20,16 +20,15 @@ resource "hashicups_coffee" "edu" {
   origin     = "Techdays 2025"
   price      = 150
   image      = "/terraform.png"
-  ingredients = [{
-    name     = "Espresso"
-    quantity = 200
-    unit     = "ml"
-    },
-    {
-      name     = "Pumpkin Spice"
+  ingredients = {
+    "Espresso" = {
+      quantity = 200
+      unit     = "ml"
+    }
+    "Pumpkin Spice" = {
       quantity = 10
       unit     = "g"
-    },
-  ]
+    }
+  }
 }
 
@@ -0,0 +1,9 @@
+# Look up a coffee by its identifier.
+data "hashicups_coffee" "by_id" {
+  id = 1
+}
+
+# Look up a coffee by its name.
+data "hashicups_coffee" "by_name" {
+  name = "Vaulatte"
+}
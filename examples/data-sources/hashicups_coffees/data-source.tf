@@ -1,2 +1,10 @@
 # List all coffees.
 data "hashicups_coffees" "all" {}
+
+# List the Origins coffees with steamed milk, cheapest first.
+data "hashicups_coffees" "milky" {
+  collection     = "Origins"
+  has_ingredient = "Steamed Milk"
+  max_price      = 300
+  sort_by        = "price"
+}
@@ -0,0 +1,8 @@
+# Read an order managed elsewhere, for example to print a receipt.
+data "hashicups_order" "example" {
+  id = "123"
+}
+
+output "order_total" {
+  value = data.hashicups_order.example.total_price
+}
@@ -0,0 +1,13 @@
+# List every order of the authenticated user.
+data "hashicups_orders" "all" {}
+
+# List only the orders containing a specific coffee.
+data "hashicups_orders" "aeropress" {
+  coffee_id = 1
+}
+
+# List only the orders placed in January 2025.
+data "hashicups_orders" "january" {
+  created_after  = "2025-01-01T00:00:00Z"
+  created_before = "2025-01-31T23:59:59Z"
+}
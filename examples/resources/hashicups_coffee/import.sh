@@ -0,0 +1,2 @@
+# Coffee can be imported by specifying the numeric identifier.
+terraform import hashicups_coffee.example 10
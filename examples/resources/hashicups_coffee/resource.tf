@@ -0,0 +1,17 @@
+# Manage example coffee.
+resource "hashicups_coffee" "example" {
+  name   = "Terraspiced Latte"
+  teaser = "Exclusively for techdays 2025"
+  price  = 3.50
+  image  = "/terraform.png"
+  ingredients = {
+    "Espresso" = {
+      quantity = 50
+      unit     = "ml"
+    }
+    "Steamed Milk" = {
+      quantity = 100
+      unit     = "ml"
+    }
+  }
+}
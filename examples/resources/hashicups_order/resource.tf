@@ -7,5 +7,11 @@ resource "hashicups_order" "example" {
       }
       quantity = 2
     },
+    {
+      coffee = {
+        name = "Nomadicano"
+      }
+      quantity = 1
+    },
   ]
 }
@@ -0,0 +1,2 @@
+# Order item can be imported by specifying the order and coffee numeric identifiers.
+terraform import hashicups_order_item.example 123/2
@@ -0,0 +1,6 @@
+# Add two Packer Spiced Lattes to an order managed elsewhere.
+resource "hashicups_order_item" "example" {
+  order_id  = "123"
+  coffee_id = 2
+  quantity  = 2
+}
@@ -23,13 +23,8 @@ func (c *Client) SignIn() (*AuthResponse, error) {
 		return nil, err
 	}
 
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
-
 	ar := AuthResponse{}
-	err = json.Unmarshal(body, &ar)
+	err = c.doJSON(req, &ar)
 	if err != nil {
 		return nil, err
 	}
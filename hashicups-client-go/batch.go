@@ -0,0 +1,188 @@
+package hashicups
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// CoffeeResult - Outcome of creating one coffee of a batch. Coffee is set
+// whenever the coffee itself was created, even if some of its ingredients
+// failed.
+type CoffeeResult struct {
+	Coffee *Coffee
+	Err    error
+}
+
+// CreateCoffees - Creates coffees together with their ingredients. The bulk
+// endpoint is used when the server provides one, otherwise the coffees are
+// created in parallel with at most MaxConcurrency requests in flight. Results
+// are in the order of coffees and the returned error joins every failure.
+func (c *Client) CreateCoffees(ctx context.Context, coffees []Coffee) ([]CoffeeResult, error) {
+	results, err := c.createCoffeesBulk(ctx, coffees)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusMethodNotAllowed) {
+		results = c.createCoffeesParallel(ctx, coffees)
+	} else if err != nil {
+		results = make([]CoffeeResult, len(coffees))
+		for i := range results {
+			results[i].Err = err
+		}
+	}
+
+	var errs []error
+	for i, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("coffee %d (%s): %w", i, coffees[i].Name, result.Err))
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+func (c *Client) createCoffeesBulk(ctx context.Context, coffees []Coffee) ([]CoffeeResult, error) {
+	rb, err := json.Marshal(coffees)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/coffees/bulk", c.HostURL), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]CoffeeResult, 0, len(coffees))
+	err = doJSONList(c, req, func(coffee Coffee) error {
+		results = append(results, CoffeeResult{Coffee: &coffee})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) != len(coffees) {
+		return nil, fmt.Errorf("bulk create returned %d coffees, expected %d", len(results), len(coffees))
+	}
+
+	return results, nil
+}
+
+func (c *Client) createCoffeesParallel(ctx context.Context, coffees []Coffee) []CoffeeResult {
+	results := make([]CoffeeResult, len(coffees))
+
+	errs := forEachLimit(ctx, len(coffees), c.maxConcurrency(), func(ctx context.Context, i int) error {
+		coffee, err := c.createCoffee(ctx, coffees[i])
+		if err != nil {
+			return err
+		}
+		results[i].Coffee = coffee
+
+		coffee.Ingredient = nil
+		for _, ingredient := range coffees[i].Ingredient {
+			newIngredient, err := c.createCoffeeIngredient(ctx, *coffee, ingredient)
+			if err != nil {
+				return fmt.Errorf("ingredient %s: %w", ingredient.Name, err)
+			}
+			coffee.Ingredient = append(coffee.Ingredient, *newIngredient)
+		}
+
+		return nil
+	})
+
+	for i, err := range errs {
+		results[i].Err = err
+	}
+
+	return results
+}
+
+// IngredientResult - Outcome of writing one ingredient of a batch
+type IngredientResult struct {
+	Ingredient *Ingredient
+	Err        error
+}
+
+// CreateCoffeeIngredients - Creates ingredients of a coffee in parallel with
+// at most MaxConcurrency requests in flight. Results are in the order of
+// ingredients and the returned error joins every failure.
+func (c *Client) CreateCoffeeIngredients(ctx context.Context, coffee Coffee, ingredients []Ingredient) ([]IngredientResult, error) {
+	return c.writeCoffeeIngredients(ctx, ingredients, func(ctx context.Context, ingredient Ingredient) (*Ingredient, error) {
+		return c.createCoffeeIngredient(ctx, coffee, ingredient)
+	})
+}
+
+// UpdateCoffeeIngredients - Updates ingredients of a coffee in parallel with
+// at most MaxConcurrency requests in flight. Results are in the order of
+// ingredients and the returned error joins every failure.
+func (c *Client) UpdateCoffeeIngredients(ctx context.Context, coffee Coffee, ingredients []Ingredient) ([]IngredientResult, error) {
+	return c.writeCoffeeIngredients(ctx, ingredients, func(ctx context.Context, ingredient Ingredient) (*Ingredient, error) {
+		return c.updateCoffeeIngredient(ctx, coffee, ingredient)
+	})
+}
+
+// DeleteCoffeeIngredients - Removes ingredients from a coffee in parallel
+// with at most MaxConcurrency requests in flight. Results are in the order of
+// ingredients and the returned error joins every failure.
+func (c *Client) DeleteCoffeeIngredients(ctx context.Context, coffee Coffee, ingredients []Ingredient) ([]IngredientResult, error) {
+	return c.writeCoffeeIngredients(ctx, ingredients, func(ctx context.Context, ingredient Ingredient) (*Ingredient, error) {
+		if err := c.deleteCoffeeIngredient(ctx, coffee, ingredient); err != nil {
+			return nil, err
+		}
+		return &ingredient, nil
+	})
+}
+
+func (c *Client) writeCoffeeIngredients(ctx context.Context, ingredients []Ingredient, write func(context.Context, Ingredient) (*Ingredient, error)) ([]IngredientResult, error) {
+	results := make([]IngredientResult, len(ingredients))
+
+	errs := forEachLimit(ctx, len(ingredients), c.maxConcurrency(), func(ctx context.Context, i int) error {
+		ingredient, err := write(ctx, ingredients[i])
+		results[i].Ingredient = ingredient
+		return err
+	})
+
+	var joined []error
+	for i, err := range errs {
+		if err != nil {
+			results[i].Err = err
+			joined = append(joined, fmt.Errorf("ingredient %d (%s): %w", i, ingredients[i].Name, err))
+		}
+	}
+
+	return results, errors.Join(joined...)
+}
+
+// forEachLimit calls fn for every index in [0, n) from at most limit
+// goroutines and returns the error of each call by index. Indexes not yet
+// started when ctx is done report the context error instead.
+func forEachLimit(ctx context.Context, n, limit int, fn func(ctx context.Context, i int) error) []error {
+	errs := make([]error, n)
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(limit, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				errs[i] = fn(ctx, i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return errs
+}
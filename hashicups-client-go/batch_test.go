@@ -0,0 +1,225 @@
+package hashicups_test
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp-demoapp/hashicups-client-go/hashicupstest"
+)
+
+// concurrencyTransport records the highest number of requests in flight.
+type concurrencyTransport struct {
+	mu       sync.Mutex
+	inFlight int
+	max      int
+}
+
+func (t *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.inFlight++
+	t.max = max(t.max, t.inFlight)
+	t.mu.Unlock()
+
+	defer func() {
+		t.mu.Lock()
+		t.inFlight--
+		t.mu.Unlock()
+	}()
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func batchOfCoffees(n int) []hashicups.Coffee {
+	coffees := []hashicups.Coffee{}
+	for i := 0; i < n; i++ {
+		coffees = append(coffees, hashicups.Coffee{
+			Name:  fmt.Sprintf("Batch Coffee %d", i),
+			Price: hashicups.MoneyFromUnits(150),
+			Ingredient: []hashicups.Ingredient{
+				{Name: "Espresso", Quantity: 40, Unit: "ml"},
+				{Name: "Hot Water", Quantity: 100, Unit: "ml"},
+			},
+		})
+	}
+	return coffees
+}
+
+func TestCreateCoffeesBulk(t *testing.T) {
+	s := hashicupstest.NewEmptyServer()
+	defer s.Close()
+	client := newTestClient(t, s)
+
+	results, err := client.CreateCoffees(context.Background(), batchOfCoffees(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, result := range results {
+		if result.Err != nil || result.Coffee == nil {
+			t.Fatalf("result %d: unexpected %+v", i, result)
+		}
+		if result.Coffee.Name != fmt.Sprintf("Batch Coffee %d", i) || len(result.Coffee.Ingredient) != 2 {
+			t.Errorf("result %d: unexpected coffee %+v", i, result.Coffee)
+		}
+	}
+}
+
+func TestCreateCoffeesFallback(t *testing.T) {
+	s := hashicupstest.NewEmptyServer()
+	defer s.Close()
+	s.DisableBulk()
+	client := newTestClient(t, s)
+
+	transport := &concurrencyTransport{}
+	client.HTTPClient.Transport = transport
+	client.MaxConcurrency = 3
+
+	coffees := batchOfCoffees(12)
+	coffees[4].Name = ""
+	coffees[9].Name = ""
+
+	results, err := client.CreateCoffees(context.Background(), coffees)
+	if err == nil {
+		t.Fatal("expected an error for the unnamed coffees")
+	}
+	for _, index := range []string{"coffee 4", "coffee 9"} {
+		if !strings.Contains(err.Error(), index) {
+			t.Errorf("expected error to mention %s, got: %v", index, err)
+		}
+	}
+
+	for i, result := range results {
+		failed := i == 4 || i == 9
+		if failed != (result.Err != nil) {
+			t.Errorf("result %d: unexpected error %v", i, result.Err)
+		}
+		if !failed && (result.Coffee == nil || result.Coffee.Name != coffees[i].Name || len(result.Coffee.Ingredient) != 2) {
+			t.Errorf("result %d: unexpected coffee %+v", i, result.Coffee)
+		}
+	}
+
+	if transport.max > 3 {
+		t.Errorf("expected at most 3 requests in flight, got %d", transport.max)
+	}
+
+	all, err := client.GetCoffees()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 10 {
+		t.Errorf("expected 10 coffees to be created, got %d", len(all))
+	}
+}
+
+func TestCreateCoffeesBulkFailure(t *testing.T) {
+	s := hashicupstest.NewEmptyServer()
+	defer s.Close()
+	client := newTestClient(t, s)
+
+	coffees := batchOfCoffees(3)
+	coffees[1].Name = ""
+
+	results, err := client.CreateCoffees(context.Background(), coffees)
+	if err == nil {
+		t.Fatal("expected the bulk request to fail")
+	}
+	for i, result := range results {
+		if result.Err == nil || result.Coffee != nil {
+			t.Errorf("result %d: expected failure without coffee, got %+v", i, result)
+		}
+	}
+}
+
+func TestCreateCoffeesCanceled(t *testing.T) {
+	s := hashicupstest.NewEmptyServer()
+	defer s.Close()
+	s.DisableBulk()
+	client := newTestClient(t, s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := client.CreateCoffees(ctx, batchOfCoffees(5))
+	if err == nil {
+		t.Fatal("expected canceled context to fail the batch")
+	}
+	for i, result := range results {
+		if result.Err == nil {
+			t.Errorf("result %d: expected an error", i)
+		}
+	}
+}
+
+func TestCoffeeIngredientBatches(t *testing.T) {
+	s := hashicupstest.NewServer()
+	defer s.Close()
+	client := newTestClient(t, s)
+
+	transport := &concurrencyTransport{}
+	client.HTTPClient.Transport = transport
+	client.MaxConcurrency = 2
+
+	coffee, err := client.CreateCoffee(hashicups.Coffee{Name: "Batch Ingredients", Price: hashicups.MoneyFromUnits(100)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ingredients := []hashicups.Ingredient{}
+	for i := 0; i < 8; i++ {
+		ingredients = append(ingredients, hashicups.Ingredient{Name: fmt.Sprintf("Syrup %d", i), Quantity: float64(i + 1), Unit: "ml"})
+	}
+
+	created, err := client.CreateCoffeeIngredients(context.Background(), *coffee, ingredients)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, result := range created {
+		if result.Ingredient == nil || result.Ingredient.Name != ingredients[i].Name || result.Ingredient.Quantity != float64(i+1) {
+			t.Errorf("result %d: unexpected ingredient %+v", i, result.Ingredient)
+		}
+		ingredients[i].ID = result.Ingredient.ID
+	}
+	if transport.max > 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", transport.max)
+	}
+
+	// Updating a missing ingredient fails without hiding the others.
+	updates := []hashicups.Ingredient{ingredients[0], {ID: 9999, Name: "Missing", Quantity: 1, Unit: "ml"}, ingredients[2]}
+	updates[0].Quantity = 50
+	updates[2].Quantity = 70
+	updated, err := client.UpdateCoffeeIngredients(context.Background(), *coffee, updates)
+	if err == nil || !strings.Contains(err.Error(), "ingredient 1 (Missing)") {
+		t.Fatalf("expected the missing ingredient to be reported, got %v", err)
+	}
+	if updated[0].Err != nil || updated[1].Err == nil || updated[2].Err != nil {
+		t.Errorf("unexpected update results: %+v", updated)
+	}
+
+	deleted, err := client.DeleteCoffeeIngredients(context.Background(), *coffee, ingredients[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deleted) != 4 {
+		t.Errorf("expected 4 delete results, got %d", len(deleted))
+	}
+
+	remaining, err := client.GetCoffeeIngredients(fmt.Sprint(coffee.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Parallel writes may land in any order on the server.
+	quantities := map[string]float64{}
+	for _, ingredient := range remaining {
+		quantities[ingredient.Name] = ingredient.Quantity
+	}
+	expected := map[string]float64{"Syrup 0": 50, "Syrup 1": 2, "Syrup 2": 70, "Syrup 3": 4}
+	if !maps.Equal(quantities, expected) {
+		t.Errorf("expected ingredients %v, got %v", expected, quantities)
+	}
+}
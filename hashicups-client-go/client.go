@@ -1,8 +1,10 @@
 package hashicups
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"time"
 )
@@ -10,12 +12,45 @@ import (
 // HostURL - Default Hashicups URL
 const HostURL string = "http://localhost:19090"
 
+// DefaultMaxResponseSize - Default limit in bytes for a single response body
+const DefaultMaxResponseSize int64 = 32 << 20
+
+// DefaultMaxConcurrency - Default number of requests a batch operation runs in parallel
+const DefaultMaxConcurrency = 4
+
+// ErrNotFound - Matches errors for objects that do not exist on the server
+var ErrNotFound = errors.New("not found")
+
+// ErrResponseTooLarge - Returned when a response body exceeds the client's MaxResponseSize
+var ErrResponseTooLarge = errors.New("response body exceeds maximum size")
+
 // Client -
 type Client struct {
 	HostURL    string
 	HTTPClient *http.Client
 	Token      string
 	Auth       AuthStruct
+	// MaxResponseSize limits how many bytes are read from a response body.
+	// Zero means DefaultMaxResponseSize.
+	MaxResponseSize int64
+	// MaxConcurrency limits how many requests batch operations run in
+	// parallel. Zero means DefaultMaxConcurrency.
+	MaxConcurrency int
+}
+
+// APIError - Returned when the server answers with an unexpected status
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// Is reports 404 Not Found answers as ErrNotFound.
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
 }
 
 // AuthStruct -
@@ -26,8 +61,8 @@ type AuthStruct struct {
 
 // AuthResponse -
 type AuthResponse struct {
-	UserID   int    `json:"user_id`
-	Username string `json:"username`
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
 	Token    string `json:"token"`
 }
 
@@ -41,6 +76,8 @@ func NewClient(host, username, password *string) (*Client, error) {
 			Username: *username,
 			Password: *password,
 		},
+		MaxResponseSize: DefaultMaxResponseSize,
+		MaxConcurrency:  DefaultMaxConcurrency,
 	}
 
 	if host != nil {
@@ -57,23 +94,122 @@ func NewClient(host, username, password *string) (*Client, error) {
 	return &c, nil
 }
 
+// doRequest returns the whole response body. It is meant for the few
+// endpoints that answer with plain text rather than JSON.
 func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	var body []byte
+	err := c.do(req, func(r io.Reader) error {
+		var err error
+		body, err = io.ReadAll(r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// doJSON decodes the response body straight into v without buffering it.
+func (c *Client) doJSON(req *http.Request, v any) error {
+	return c.do(req, func(r io.Reader) error {
+		return json.NewDecoder(r).Decode(v)
+	})
+}
+
+// doJSONList decodes a JSON array response one element at a time and hands
+// each element to fn, so list endpoints never hold the raw body in memory.
+func doJSONList[T any](c *Client, req *http.Request, fn func(T) error) error {
+	return c.do(req, func(r io.Reader) error {
+		dec := json.NewDecoder(r)
+
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if tok == nil {
+			// A null body is an empty list.
+			return nil
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return fmt.Errorf("expected JSON array, got: %v", tok)
+		}
+
+		for dec.More() {
+			var item T
+			if err := dec.Decode(&item); err != nil {
+				return err
+			}
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+
+		// Consume the closing bracket so truncated bodies are reported.
+		_, err = dec.Token()
+		return err
+	})
+}
+
+// do sends the request and passes the size-limited response body to decode
+// when the server answers with 200 OK.
+func (c *Client) do(req *http.Request, decode func(io.Reader) error) error {
 	req.Header.Set("Authorization", c.Token)
 
 	res, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer res.Body.Close()
 
-	body, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
+	body := &maxBytesReader{r: res.Body, n: c.maxResponseSize()}
 
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+		b, err := io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		return &APIError{StatusCode: res.StatusCode, Body: string(b)}
+	}
+
+	return decode(body)
+}
+
+func (c *Client) maxResponseSize() int64 {
+	if c.MaxResponseSize > 0 {
+		return c.MaxResponseSize
+	}
+	return DefaultMaxResponseSize
+}
+
+func (c *Client) maxConcurrency() int {
+	if c.MaxConcurrency > 0 {
+		return c.MaxConcurrency
+	}
+	return DefaultMaxConcurrency
+}
+
+// maxBytesReader reads at most n bytes from r and fails with
+// ErrResponseTooLarge, instead of a silent EOF, if r has more to give.
+type maxBytesReader struct {
+	r io.Reader
+	n int64
+}
+
+func (m *maxBytesReader) Read(p []byte) (int, error) {
+	if m.n <= 0 {
+		var probe [1]byte
+		n, err := m.r.Read(probe[:])
+		if n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+		return 0, err
 	}
 
-	return body, err
+	if int64(len(p)) > m.n {
+		p = p[:m.n]
+	}
+	n, err := m.r.Read(p)
+	m.n -= int64(n)
+	return n, err
 }
@@ -0,0 +1,174 @@
+package hashicups_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp-demoapp/hashicups-client-go/hashicupstest"
+)
+
+func newTestClient(t testing.TB, s *hashicupstest.Server) *hashicups.Client {
+	t.Helper()
+
+	client, err := s.NewClient()
+	if err != nil {
+		t.Fatalf("signing in to test server: %v", err)
+	}
+	return client
+}
+
+func TestGetCoffees(t *testing.T) {
+	s := hashicupstest.NewServer()
+	defer s.Close()
+	client := newTestClient(t, s)
+
+	coffees, err := client.GetCoffees()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(coffees) != 9 {
+		t.Fatalf("expected 9 coffees, got %d", len(coffees))
+	}
+	if coffees[0].Name != "HCP Aeropress" || coffees[0].Price != hashicups.MoneyFromUnits(200) {
+		t.Errorf("unexpected first coffee: %+v", coffees[0])
+	}
+	if len(coffees[1].Ingredient) != 3 {
+		t.Errorf("expected 3 ingredients on %q, got %d", coffees[1].Name, len(coffees[1].Ingredient))
+	}
+}
+
+func TestGetCoffeesEmpty(t *testing.T) {
+	s := hashicupstest.NewEmptyServer()
+	defer s.Close()
+	client := newTestClient(t, s)
+
+	coffees, err := client.GetCoffees()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coffees) != 0 {
+		t.Fatalf("expected no coffees, got %d", len(coffees))
+	}
+}
+
+func TestGetCoffee(t *testing.T) {
+	s := hashicupstest.NewServer()
+	defer s.Close()
+	client := newTestClient(t, s)
+
+	coffee, err := client.GetCoffee("2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if coffee.Name != "Packer Spiced Latte" {
+		t.Errorf("expected Packer Spiced Latte, got %q", coffee.Name)
+	}
+
+	_, err = client.GetCoffee("999")
+	if !errors.Is(err, hashicups.ErrNotFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestMaxResponseSize(t *testing.T) {
+	s := hashicupstest.NewServer()
+	defer s.Close()
+	client := newTestClient(t, s)
+
+	client.MaxResponseSize = 64
+
+	_, err := client.GetCoffees()
+	if !errors.Is(err, hashicups.ErrResponseTooLarge) {
+		t.Fatalf("expected ErrResponseTooLarge, got %v", err)
+	}
+
+	client.MaxResponseSize = 0
+
+	if _, err := client.GetCoffees(); err != nil {
+		t.Fatalf("expected default limit to fit the catalog, got %v", err)
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	s := hashicupstest.NewServer()
+	defer s.Close()
+	client := newTestClient(t, s)
+
+	_, err := client.GetOrder("42")
+	if err == nil || !strings.Contains(err.Error(), "status: 404") {
+		t.Fatalf("expected status 404 error, got %v", err)
+	}
+	if !errors.Is(err, hashicups.ErrNotFound) {
+		t.Errorf("expected 404 to match ErrNotFound, got %v", err)
+	}
+}
+
+// seedLargeCatalog fills the server with enough coffees to make the cost of
+// buffering the response body visible in benchmarks.
+func seedLargeCatalog(s *hashicupstest.Server, n int) {
+	description := strings.Repeat("A carefully roasted blend. ", 40)
+	for i := 0; i < n; i++ {
+		s.AddCoffee(hashicups.Coffee{
+			Name:        fmt.Sprintf("Coffee %d", i),
+			Teaser:      "Benchmark brew",
+			Description: description,
+			Price:       hashicups.MoneyFromUnits(int64(100 + i%400)),
+			Image:       "/terraform.png",
+			Ingredient: []hashicups.Ingredient{
+				{Name: "Espresso", Quantity: 40, Unit: "ml"},
+				{Name: "Steamed Milk", Quantity: 300, Unit: "ml"},
+				{Name: "Pumpkin Spice", Quantity: 5, Unit: "g"},
+			},
+		})
+	}
+}
+
+func BenchmarkGetCoffees(b *testing.B) {
+	s := hashicupstest.NewEmptyServer()
+	defer s.Close()
+	seedLargeCatalog(s, 5000)
+	client := newTestClient(b, s)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.GetCoffees(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGetCoffeesReadAll is the buffered baseline that GetCoffees used
+// before switching to streaming decoding.
+func BenchmarkGetCoffeesReadAll(b *testing.B) {
+	s := hashicupstest.NewEmptyServer()
+	defer s.Close()
+	seedLargeCatalog(s, 5000)
+	httpClient := &http.Client{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		res, err := httpClient.Get(s.URL + "/coffees")
+		if err != nil {
+			b.Fatal(err)
+		}
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		coffees := []hashicups.Coffee{}
+		if err := json.Unmarshal(body, &coffees); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package hashicups
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -14,13 +16,11 @@ func (c *Client) GetCoffees() ([]Coffee, error) {
 		return nil, err
 	}
 
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
-
 	coffees := []Coffee{}
-	err = json.Unmarshal(body, &coffees)
+	err = doJSONList(c, req, func(coffee Coffee) error {
+		coffees = append(coffees, coffee)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -35,15 +35,17 @@ func (c *Client) GetCoffee(coffeeID string) (*Coffee, error) {
 		return nil, err
 	}
 
-	body, err := c.doRequest(req)
+	coffee := []Coffee{}
+	err = doJSONList(c, req, func(item Coffee) error {
+		coffee = append(coffee, item)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	coffee := []Coffee{}
-	err = json.Unmarshal(body, &coffee)
-	if err != nil {
-		return nil, err
+	if len(coffee) == 0 {
+		return nil, fmt.Errorf("coffee %s: %w", coffeeID, ErrNotFound)
 	}
 
 	return &coffee[0], nil
@@ -56,13 +58,11 @@ func (c *Client) GetCoffeeIngredients(coffeeID string) ([]Ingredient, error) {
 		return nil, err
 	}
 
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
-
 	ingredients := []Ingredient{}
-	err = json.Unmarshal(body, &ingredients)
+	err = doJSONList(c, req, func(ingredient Ingredient) error {
+		ingredients = append(ingredients, ingredient)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -72,23 +72,22 @@ func (c *Client) GetCoffeeIngredients(coffeeID string) ([]Ingredient, error) {
 
 // CreateCoffee - Create new coffee
 func (c *Client) CreateCoffee(coffee Coffee) (*Coffee, error) {
-	rb, err := json.Marshal(coffee)
-	if err != nil {
-		return nil, err
-	}
+	return c.createCoffee(context.Background(), coffee)
+}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/coffees", c.HostURL), strings.NewReader(string(rb)))
+func (c *Client) createCoffee(ctx context.Context, coffee Coffee) (*Coffee, error) {
+	rb, err := json.Marshal(coffee)
 	if err != nil {
 		return nil, err
 	}
 
-	body, err := c.doRequest(req)
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/coffees", c.HostURL), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
 
 	newCoffee := Coffee{}
-	err = json.Unmarshal(body, &newCoffee)
+	err = c.doJSON(req, &newCoffee)
 	if err != nil {
 		return nil, err
 	}
@@ -108,14 +107,8 @@ func (c *Client) UpdateCoffee(coffee Coffee) (*Coffee, error) {
 		return nil, err
 	}
 
-	body, err := c.doRequest(req)
-	fmt.Printf("body: %v\n", body)
-	if err != nil {
-		return nil, err
-	}
-
 	newCoffee := Coffee{}
-	err = json.Unmarshal(body, &newCoffee)
+	err = c.doJSON(req, &newCoffee)
 	if err != nil {
 		return nil, err
 	}
@@ -128,22 +121,22 @@ func (c *Client) DeleteCoffee(coffeeId string) error {
 	if err != nil {
 		return err
 	}
-	body, err := c.doRequest(req)
-	if err != nil {
-		return err
-	}
-	fmt.Printf("body: %v\n", body)
-	return nil
+	_, err = c.doRequest(req)
+	return err
 }
 
 // CreateCoffeeIngredient - Create new coffee ingredient
 func (c *Client) CreateCoffeeIngredient(coffee Coffee, ingredient Ingredient) (*Ingredient, error) {
+	return c.createCoffeeIngredient(context.Background(), coffee, ingredient)
+}
+
+func (c *Client) createCoffeeIngredient(ctx context.Context, coffee Coffee, ingredient Ingredient) (*Ingredient, error) {
 	reqBody := struct {
-		CoffeeID     int    `json:"coffee_id"`
-		IngredientID int    `json:"ingredient_id"`
-		Name         string `json:"name"`
-		Quantity     int    `json:"quantity"`
-		Unit         string `json:"unit"`
+		CoffeeID     int     `json:"coffee_id"`
+		IngredientID int     `json:"ingredient_id"`
+		Name         string  `json:"name"`
+		Quantity     float64 `json:"quantity"`
+		Unit         string  `json:"unit"`
 	}{
 		CoffeeID:     coffee.ID,
 		IngredientID: ingredient.ID,
@@ -156,21 +149,64 @@ func (c *Client) CreateCoffeeIngredient(coffee Coffee, ingredient Ingredient) (*
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/coffees/%d/ingredients", c.HostURL, coffee.ID), strings.NewReader(string(rb)))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/coffees/%d/ingredients", c.HostURL, coffee.ID), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
 
-	body, err := c.doRequest(req)
+	newIngredient := Ingredient{}
+	err = c.doJSON(req, &newIngredient)
 	if err != nil {
 		return nil, err
 	}
 
-	newIngredient := Ingredient{}
-	err = json.Unmarshal(body, &newIngredient)
+	return &newIngredient, nil
+}
+
+// UpdateCoffeeIngredient - Updates the quantity and unit of a coffee ingredient
+func (c *Client) UpdateCoffeeIngredient(coffee Coffee, ingredient Ingredient) (*Ingredient, error) {
+	return c.updateCoffeeIngredient(context.Background(), coffee, ingredient)
+}
+
+func (c *Client) updateCoffeeIngredient(ctx context.Context, coffee Coffee, ingredient Ingredient) (*Ingredient, error) {
+	rb, err := json.Marshal(ingredient)
 	if err != nil {
 		return nil, err
 	}
 
-	return &newIngredient, nil
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/coffees/%d/ingredients/%d", c.HostURL, coffee.ID, ingredient.ID), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	updatedIngredient := Ingredient{}
+	err = c.doJSON(req, &updatedIngredient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedIngredient, nil
+}
+
+// DeleteCoffeeIngredient - Removes an ingredient from a coffee
+func (c *Client) DeleteCoffeeIngredient(coffee Coffee, ingredient Ingredient) error {
+	return c.deleteCoffeeIngredient(context.Background(), coffee, ingredient)
+}
+
+func (c *Client) deleteCoffeeIngredient(ctx context.Context, coffee Coffee, ingredient Ingredient) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/coffees/%d/ingredients/%d", c.HostURL, coffee.ID, ingredient.ID), nil)
+	if err != nil {
+		return err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if string(body) != "Deleted ingredient" {
+		return errors.New(string(body))
+	}
+
+	return nil
 }
@@ -0,0 +1,87 @@
+package hashicups_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp-demoapp/hashicups-client-go/hashicupstest"
+)
+
+func TestCoffeeIngredientLifecycle(t *testing.T) {
+	s := hashicupstest.NewServer()
+	defer s.Close()
+	client := newTestClient(t, s)
+
+	coffee, err := client.CreateCoffee(hashicups.Coffee{Name: "Ingredient Test", Price: hashicups.MoneyFromUnits(100)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	espresso, err := client.CreateCoffeeIngredient(*coffee, hashicups.Ingredient{Name: "Espresso", Quantity: 40, Unit: "ml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	milk, err := client.CreateCoffeeIngredient(*coffee, hashicups.Ingredient{Name: "Steamed Milk", Quantity: 200, Unit: "ml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	espresso.Quantity = 1.5
+	updated, err := client.UpdateCoffeeIngredient(*coffee, *espresso)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.ID != espresso.ID || updated.Quantity != 1.5 || updated.Name != "Espresso" {
+		t.Errorf("unexpected updated ingredient: %+v", updated)
+	}
+
+	if err := client.DeleteCoffeeIngredient(*coffee, *milk); err != nil {
+		t.Fatal(err)
+	}
+
+	ingredients, err := client.GetCoffeeIngredients("5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ingredients) != 1 {
+		t.Fatalf("seeded coffee ingredients changed: %+v", ingredients)
+	}
+
+	ingredients, err = client.GetCoffeeIngredients(strconv.Itoa(coffee.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ingredients) != 1 || ingredients[0].Name != "Espresso" || ingredients[0].Quantity != 1.5 {
+		t.Fatalf("expected only the updated espresso, got %+v", ingredients)
+	}
+
+	if err := client.DeleteCoffeeIngredient(*coffee, *milk); err == nil {
+		t.Error("expected deleting a removed ingredient to fail")
+	}
+}
+
+func TestCoffeeColorAndDescription(t *testing.T) {
+	s := hashicupstest.NewServer()
+	defer s.Close()
+	client := newTestClient(t, s)
+
+	coffee, err := client.CreateCoffee(hashicups.Coffee{Name: "Color Test", Price: hashicups.MoneyFromUnits(100), Color: "#444", Description: "Dark"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coffee.Color = "#0F3B5C"
+	coffee.Description = "Lighter"
+	if _, err := client.UpdateCoffee(*coffee); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := client.GetCoffee(strconv.Itoa(coffee.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Color != "#0F3B5C" || got.Description != "Lighter" {
+		t.Errorf("expected updated color and description, got %q and %q", got.Color, got.Description)
+	}
+}
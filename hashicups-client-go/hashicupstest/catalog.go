@@ -0,0 +1,116 @@
+package hashicupstest
+
+import "github.com/hashicorp-demoapp/hashicups-client-go"
+
+// defaultCatalog mirrors the coffees shipped with the HashiCups demo
+// application.
+func defaultCatalog() []hashicups.Coffee {
+	return []hashicups.Coffee{
+		{
+			Name:       "HCP Aeropress",
+			Teaser:     "Automation in a cup",
+			Collection: "Foundations",
+			Origin:     "Summer 2020",
+			Price:      hashicups.MoneyFromUnits(200),
+			Image:      "/hashicorp.png",
+			Ingredient: []hashicups.Ingredient{
+				{Name: "Espresso", Quantity: 40, Unit: "ml"},
+			},
+		},
+		{
+			Name:       "Packer Spiced Latte",
+			Teaser:     "Packed with goodness to spice up your images",
+			Collection: "Origins",
+			Origin:     "Summer 2013",
+			Price:      hashicups.MoneyFromUnits(350),
+			Image:      "/packer.png",
+			Ingredient: []hashicups.Ingredient{
+				{Name: "Espresso", Quantity: 40, Unit: "ml"},
+				{Name: "Steamed Milk", Quantity: 300, Unit: "ml"},
+				{Name: "Pumpkin Spice", Quantity: 5, Unit: "g"},
+			},
+		},
+		{
+			Name:       "Vaulatte",
+			Teaser:     "Nothing gives you a safe and secure feeling like a Vaulatte",
+			Collection: "Origins",
+			Origin:     "Spring 2015",
+			Price:      hashicups.MoneyFromUnits(200),
+			Image:      "/vault.png",
+			Ingredient: []hashicups.Ingredient{
+				{Name: "Espresso", Quantity: 40, Unit: "ml"},
+				{Name: "Steamed Milk", Quantity: 300, Unit: "ml"},
+			},
+		},
+		{
+			Name:       "Nomadicano",
+			Teaser:     "Drink one today and you will want to schedule another",
+			Collection: "Origins",
+			Origin:     "Fall 2015",
+			Price:      hashicups.MoneyFromUnits(150),
+			Image:      "/nomad.png",
+			Ingredient: []hashicups.Ingredient{
+				{Name: "Espresso", Quantity: 20, Unit: "ml"},
+				{Name: "Hot Water", Quantity: 100, Unit: "ml"},
+			},
+		},
+		{
+			Name:       "Terraspresso",
+			Teaser:     "Nothing kickstarts your day like a provision of Terraspresso",
+			Collection: "Origins",
+			Origin:     "Summer 2014",
+			Price:      hashicups.MoneyFromUnits(150),
+			Image:      "/terraform.png",
+			Ingredient: []hashicups.Ingredient{
+				{Name: "Espresso", Quantity: 40, Unit: "ml"},
+			},
+		},
+		{
+			Name:       "Vagrante espresso",
+			Teaser:     "Stdin is not a tty",
+			Collection: "Origins",
+			Origin:     "Fall 2010",
+			Price:      hashicups.MoneyFromUnits(200),
+			Image:      "/vagrant.png",
+			Ingredient: []hashicups.Ingredient{
+				{Name: "Espresso", Quantity: 40, Unit: "ml"},
+			},
+		},
+		{
+			Name:       "Connectaccino",
+			Teaser:     "Discover the wonders of our meshy service",
+			Collection: "Origins",
+			Origin:     "Spring 2014",
+			Price:      hashicups.MoneyFromUnits(250),
+			Image:      "/consul.png",
+			Ingredient: []hashicups.Ingredient{
+				{Name: "Espresso", Quantity: 40, Unit: "ml"},
+				{Name: "Semi Skimmed Milk", Quantity: 300, Unit: "ml"},
+			},
+		},
+		{
+			Name:       "Boundary Red Eye",
+			Teaser:     "Perk up and watch out for your access management",
+			Collection: "Origins",
+			Origin:     "Fall 2020",
+			Price:      hashicups.MoneyFromUnits(200),
+			Image:      "/boundary.png",
+			Ingredient: []hashicups.Ingredient{
+				{Name: "Espresso", Quantity: 40, Unit: "ml"},
+				{Name: "Hot Water", Quantity: 100, Unit: "ml"},
+			},
+		},
+		{
+			Name:       "Waypointiato",
+			Teaser:     "Deploy with a little foam",
+			Collection: "Origins",
+			Origin:     "Fall 2020",
+			Price:      hashicups.MoneyFromUnits(250),
+			Image:      "/waypoint.png",
+			Ingredient: []hashicups.Ingredient{
+				{Name: "Espresso", Quantity: 40, Unit: "ml"},
+				{Name: "Semi Skimmed Milk", Quantity: 100, Unit: "ml"},
+			},
+		},
+	}
+}
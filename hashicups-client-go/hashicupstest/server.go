@@ -0,0 +1,737 @@
+// Package hashicupstest provides an in-memory stand-in for the HashiCups API
+// that can be used by client and provider tests.
+package hashicupstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+)
+
+// Default credentials accepted by the server.
+const (
+	Username = "education"
+	Password = "test123"
+	Token    = "hashicupstest-token"
+)
+
+// Server - An in-memory HashiCups API listening on a local address
+type Server struct {
+	*httptest.Server
+
+	mu               sync.Mutex
+	coffees          []hashicups.Coffee
+	orders           []hashicups.Order
+	ingredientIDs    map[string]int
+	nextCoffeeID     int
+	nextIngredientID int
+	nextOrderID      int
+	bulkDisabled     bool
+	failIngredients  map[string]bool
+}
+
+// NewServer - Starts a server seeded with the default HashiCups catalog.
+// Callers should Close it when finished.
+func NewServer() *Server {
+	s := NewEmptyServer()
+	for _, coffee := range defaultCatalog() {
+		s.AddCoffee(coffee)
+	}
+	return s
+}
+
+// NewEmptyServer - Starts a server with an empty catalog
+func NewEmptyServer() *Server {
+	s := &Server{
+		ingredientIDs:    map[string]int{},
+		failIngredients:  map[string]bool{},
+		nextCoffeeID:     1,
+		nextIngredientID: 1,
+		nextOrderID:      1,
+	}
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// NewClient - Returns a client signed in to the server
+func (s *Server) NewClient() (*hashicups.Client, error) {
+	host, username, password := s.URL, Username, Password
+	return hashicups.NewClient(&host, &username, &password)
+}
+
+// AddCoffee - Adds a coffee with its ingredients to the catalog and returns
+// it with the assigned identifiers.
+func (s *Server) AddCoffee(coffee hashicups.Coffee) hashicups.Coffee {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	coffee.ID = s.nextCoffeeID
+	s.nextCoffeeID++
+
+	ingredients := coffee.Ingredient
+	coffee.Ingredient = nil
+	for _, ingredient := range ingredients {
+		ingredient.ID = s.ingredientID(ingredient.Name)
+		coffee.Ingredient = append(coffee.Ingredient, ingredient)
+	}
+
+	s.coffees = append(s.coffees, coffee)
+	return coffee
+}
+
+// Coffee - Returns a copy of the stored coffee
+func (s *Server) Coffee(id int) (hashicups.Coffee, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.coffeeIndex(id)
+	if i < 0 {
+		return hashicups.Coffee{}, false
+	}
+	return cloneCoffee(s.coffees[i]), true
+}
+
+// SetCoffee - Replaces a stored coffee, as if it had been edited outside of
+// the client.
+func (s *Server) SetCoffee(coffee hashicups.Coffee) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.coffeeIndex(coffee.ID)
+	if i < 0 {
+		return false
+	}
+	s.coffees[i] = cloneCoffee(coffee)
+	return true
+}
+
+// DisableBulk - Makes the bulk coffee endpoint answer with 404 Not Found, like
+// servers that do not support it.
+func (s *Server) DisableBulk() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.bulkDisabled = true
+}
+
+// FailIngredient - Makes adding an ingredient with the given name to any
+// coffee fail with 500 Internal Server Error.
+func (s *Server) FailIngredient(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failIngredients[name] = true
+}
+
+// ClearFaults - Undoes every FailIngredient call
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.failIngredients)
+}
+
+// Coffees - Returns a copy of the catalog
+func (s *Server) Coffees() []hashicups.Coffee {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	coffees := make([]hashicups.Coffee, 0, len(s.coffees))
+	for _, coffee := range s.coffees {
+		coffees = append(coffees, cloneCoffee(coffee))
+	}
+	return coffees
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /signin", s.signIn)
+	mux.HandleFunc("POST /signout", s.authorized(s.signOut))
+
+	mux.HandleFunc("GET /coffees", s.listCoffees)
+	mux.HandleFunc("POST /coffees", s.authorized(s.createCoffee))
+	mux.HandleFunc("POST /coffees/bulk", s.authorized(s.createCoffees))
+	mux.HandleFunc("GET /coffees/{id}", s.getCoffee)
+	mux.HandleFunc("PUT /coffees/{id}", s.authorized(s.updateCoffee))
+	mux.HandleFunc("DELETE /coffees/{id}", s.authorized(s.deleteCoffee))
+	mux.HandleFunc("GET /coffees/{id}/ingredients", s.listIngredients)
+	mux.HandleFunc("POST /coffees/{id}/ingredients", s.authorized(s.createIngredient))
+	mux.HandleFunc("PUT /coffees/{id}/ingredients/{ingredient_id}", s.authorized(s.updateIngredient))
+	mux.HandleFunc("DELETE /coffees/{id}/ingredients/{ingredient_id}", s.authorized(s.deleteIngredient))
+
+	mux.HandleFunc("GET /orders", s.authorized(s.listOrders))
+	mux.HandleFunc("POST /orders", s.authorized(s.createOrder))
+	mux.HandleFunc("GET /orders/{id}", s.authorized(s.getOrder))
+	mux.HandleFunc("PUT /orders/{id}", s.authorized(s.updateOrder))
+	mux.HandleFunc("DELETE /orders/{id}", s.authorized(s.deleteOrder))
+	mux.HandleFunc("POST /orders/{id}/items", s.authorized(s.addOrderItem))
+	mux.HandleFunc("PUT /orders/{id}/items/{coffee_id}", s.authorized(s.updateOrderItem))
+	mux.HandleFunc("DELETE /orders/{id}/items/{coffee_id}", s.authorized(s.deleteOrderItem))
+
+	return mux
+}
+
+func (s *Server) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != Token {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) signIn(w http.ResponseWriter, r *http.Request) {
+	auth := hashicups.AuthStruct{}
+	if err := json.NewDecoder(r.Body).Decode(&auth); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if auth.Username != Username || auth.Password != Password {
+		http.Error(w, "Invalid Credentials", http.StatusUnauthorized)
+		return
+	}
+
+	writeJSON(w, hashicups.AuthResponse{UserID: 1, Username: auth.Username, Token: Token})
+}
+
+func (s *Server) signOut(w http.ResponseWriter, _ *http.Request) {
+	_, _ = w.Write([]byte("Signed out user"))
+}
+
+func (s *Server) listCoffees(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, s.Coffees())
+}
+
+func (s *Server) getCoffee(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	// The HashiCups API answers with a list even for a single coffee.
+	coffees := []hashicups.Coffee{}
+	if coffee, ok := s.Coffee(id); ok {
+		coffees = append(coffees, coffee)
+	}
+	writeJSON(w, coffees)
+}
+
+func (s *Server) createCoffee(w http.ResponseWriter, r *http.Request) {
+	coffee := hashicups.Coffee{}
+	if err := json.NewDecoder(r.Body).Decode(&coffee); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if coffee.Name == "" {
+		http.Error(w, "Coffee name is required", http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, s.AddCoffee(coffee))
+}
+
+// createCoffees creates a batch of coffees with their ingredients. Either all
+// of them are created or none.
+func (s *Server) createCoffees(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	disabled := s.bulkDisabled
+	s.mu.Unlock()
+	if disabled {
+		http.NotFound(w, r)
+		return
+	}
+
+	coffees := []hashicups.Coffee{}
+	if err := json.NewDecoder(r.Body).Decode(&coffees); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for i, coffee := range coffees {
+		if coffee.Name == "" {
+			http.Error(w, "Coffee "+strconv.Itoa(i)+": name is required", http.StatusBadRequest)
+			return
+		}
+	}
+
+	created := make([]hashicups.Coffee, 0, len(coffees))
+	for _, coffee := range coffees {
+		created = append(created, s.AddCoffee(coffee))
+	}
+	writeJSON(w, created)
+}
+
+func (s *Server) updateCoffee(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	coffee := hashicups.Coffee{}
+	if err := json.NewDecoder(r.Body).Decode(&coffee); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.coffeeIndex(id)
+	if i < 0 {
+		http.Error(w, "Coffee not found", http.StatusNotFound)
+		return
+	}
+
+	// Ingredients are managed through their own endpoints.
+	coffee.ID = id
+	coffee.Ingredient = s.coffees[i].Ingredient
+	s.coffees[i] = coffee
+
+	writeJSON(w, cloneCoffee(coffee))
+}
+
+func (s *Server) deleteCoffee(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.coffeeIndex(id)
+	if i < 0 {
+		http.Error(w, "Coffee not found", http.StatusNotFound)
+		return
+	}
+	s.coffees = slices.Delete(s.coffees, i, i+1)
+
+	_, _ = w.Write([]byte("Deleted coffee"))
+}
+
+func (s *Server) listIngredients(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	coffee, ok := s.Coffee(id)
+	if !ok {
+		http.Error(w, "Coffee not found", http.StatusNotFound)
+		return
+	}
+
+	ingredients := coffee.Ingredient
+	if ingredients == nil {
+		ingredients = []hashicups.Ingredient{}
+	}
+	writeJSON(w, ingredients)
+}
+
+// createIngredient adds an ingredient to a coffee. Posting an ingredient the
+// coffee already has replaces its quantity and unit, and a zero quantity
+// removes it.
+func (s *Server) createIngredient(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	ingredient := hashicups.Ingredient{}
+	if err := json.NewDecoder(r.Body).Decode(&ingredient); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.coffeeIndex(id)
+	if i < 0 {
+		http.Error(w, "Coffee not found", http.StatusNotFound)
+		return
+	}
+	coffee := &s.coffees[i]
+
+	if s.failIngredients[ingredient.Name] {
+		http.Error(w, "Unable to add ingredient "+ingredient.Name, http.StatusInternalServerError)
+		return
+	}
+
+	ingredient.ID = s.ingredientID(ingredient.Name)
+	existing := slices.IndexFunc(coffee.Ingredient, func(in hashicups.Ingredient) bool { return in.ID == ingredient.ID })
+	switch {
+	case existing >= 0 && ingredient.Quantity == 0:
+		coffee.Ingredient = slices.Delete(coffee.Ingredient, existing, existing+1)
+	case existing >= 0:
+		coffee.Ingredient[existing] = ingredient
+	default:
+		coffee.Ingredient = append(coffee.Ingredient, ingredient)
+	}
+
+	writeJSON(w, ingredient)
+}
+
+func (s *Server) updateIngredient(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	ingredientID, ok := pathID(w, r, "ingredient_id")
+	if !ok {
+		return
+	}
+
+	ingredient := hashicups.Ingredient{}
+	if err := json.NewDecoder(r.Body).Decode(&ingredient); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	coffee, existing := s.coffeeIngredient(w, id, ingredientID)
+	if existing < 0 {
+		return
+	}
+
+	// The name identifies the ingredient and cannot be changed.
+	ingredient.ID = ingredientID
+	ingredient.Name = coffee.Ingredient[existing].Name
+	coffee.Ingredient[existing] = ingredient
+
+	writeJSON(w, ingredient)
+}
+
+func (s *Server) deleteIngredient(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	ingredientID, ok := pathID(w, r, "ingredient_id")
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	coffee, existing := s.coffeeIngredient(w, id, ingredientID)
+	if existing < 0 {
+		return
+	}
+	coffee.Ingredient = slices.Delete(coffee.Ingredient, existing, existing+1)
+
+	_, _ = w.Write([]byte("Deleted ingredient"))
+}
+
+// coffeeIngredient looks up an ingredient of a coffee, answering with 404 and
+// returning a negative index when either is missing. It must be called with
+// s.mu held.
+func (s *Server) coffeeIngredient(w http.ResponseWriter, coffeeID, ingredientID int) (*hashicups.Coffee, int) {
+	i := s.coffeeIndex(coffeeID)
+	if i < 0 {
+		http.Error(w, "Coffee not found", http.StatusNotFound)
+		return nil, -1
+	}
+	coffee := &s.coffees[i]
+
+	existing := slices.IndexFunc(coffee.Ingredient, func(in hashicups.Ingredient) bool { return in.ID == ingredientID })
+	if existing < 0 {
+		http.Error(w, "Ingredient not found", http.StatusNotFound)
+	}
+	return coffee, existing
+}
+
+func (s *Server) listOrders(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	orders := slices.Clone(s.orders)
+	s.mu.Unlock()
+
+	if orders == nil {
+		orders = []hashicups.Order{}
+	}
+	writeJSON(w, orders)
+}
+
+func (s *Server) createOrder(w http.ResponseWriter, r *http.Request) {
+	items := []hashicups.OrderItem{}
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	order := hashicups.Order{ID: s.nextOrderID, CreatedAt: &now, UpdatedAt: &now}
+	if !s.fillOrderItems(w, &order, items) {
+		return
+	}
+	s.nextOrderID++
+	s.orders = append(s.orders, order)
+
+	writeJSON(w, order)
+}
+
+func (s *Server) getOrder(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.orderIndex(id)
+	if i < 0 {
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, s.orders[i])
+}
+
+func (s *Server) updateOrder(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	items := []hashicups.OrderItem{}
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.orderIndex(id)
+	if i < 0 {
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return
+	}
+
+	now := s.now()
+	order := hashicups.Order{ID: id, CreatedAt: s.orders[i].CreatedAt, UpdatedAt: &now}
+	if !s.fillOrderItems(w, &order, items) {
+		return
+	}
+	s.orders[i] = order
+
+	writeJSON(w, order)
+}
+
+func (s *Server) deleteOrder(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.orderIndex(id)
+	if i < 0 {
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return
+	}
+	s.orders = slices.Delete(s.orders, i, i+1)
+
+	_, _ = w.Write([]byte("Deleted order"))
+}
+
+func (s *Server) addOrderItem(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	item := hashicups.OrderItem{}
+	if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.orderIndex(id)
+	if i < 0 {
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return
+	}
+	order := &s.orders[i]
+
+	if orderItemIndex(*order, item.Coffee.ID) >= 0 {
+		http.Error(w, "Coffee "+strconv.Itoa(item.Coffee.ID)+" is already in the order", http.StatusConflict)
+		return
+	}
+	if !s.fillOrderItems(w, order, []hashicups.OrderItem{item}) {
+		return
+	}
+	now := s.now()
+	order.UpdatedAt = &now
+
+	writeJSON(w, *order)
+}
+
+func (s *Server) updateOrderItem(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	coffeeID, ok := pathID(w, r, "coffee_id")
+	if !ok {
+		return
+	}
+
+	item := hashicups.OrderItem{}
+	if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, existing := s.orderItem(w, id, coffeeID)
+	if existing < 0 {
+		return
+	}
+	order.Items[existing].Quantity = item.Quantity
+	now := s.now()
+	order.UpdatedAt = &now
+
+	writeJSON(w, *order)
+}
+
+func (s *Server) deleteOrderItem(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	coffeeID, ok := pathID(w, r, "coffee_id")
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, existing := s.orderItem(w, id, coffeeID)
+	if existing < 0 {
+		return
+	}
+	order.Items = slices.Delete(order.Items, existing, existing+1)
+	now := s.now()
+	order.UpdatedAt = &now
+
+	_, _ = w.Write([]byte("Deleted order item"))
+}
+
+// orderItem looks up the item for a coffee in an order, answering with 404
+// and returning a negative index when either is missing. It must be called
+// with s.mu held.
+func (s *Server) orderItem(w http.ResponseWriter, orderID, coffeeID int) (*hashicups.Order, int) {
+	i := s.orderIndex(orderID)
+	if i < 0 {
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return nil, -1
+	}
+	order := &s.orders[i]
+
+	existing := orderItemIndex(*order, coffeeID)
+	if existing < 0 {
+		http.Error(w, "Order item not found", http.StatusNotFound)
+	}
+	return order, existing
+}
+
+// SetOrderCreatedAt - Overrides the creation time of a stored order
+func (s *Server) SetOrderCreatedAt(id int, createdAt time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.orderIndex(id)
+	if i < 0 {
+		return false
+	}
+	s.orders[i].CreatedAt = &createdAt
+	return true
+}
+
+// fillOrderItems resolves the coffees referenced by items from the catalog.
+// It must be called with s.mu held.
+func (s *Server) fillOrderItems(w http.ResponseWriter, order *hashicups.Order, items []hashicups.OrderItem) bool {
+	for _, item := range items {
+		i := s.coffeeIndex(item.Coffee.ID)
+		if i < 0 {
+			http.Error(w, "Coffee "+strconv.Itoa(item.Coffee.ID)+" not found", http.StatusInternalServerError)
+			return false
+		}
+		order.Items = append(order.Items, hashicups.OrderItem{
+			Coffee:   cloneCoffee(s.coffees[i]),
+			Quantity: item.Quantity,
+		})
+	}
+	return true
+}
+
+// ingredientID returns the catalog-wide identifier of an ingredient name,
+// registering the name if it is new. It must be called with s.mu held.
+func (s *Server) ingredientID(name string) int {
+	if id, ok := s.ingredientIDs[name]; ok {
+		return id
+	}
+	id := s.nextIngredientID
+	s.nextIngredientID++
+	s.ingredientIDs[name] = id
+	return id
+}
+
+func (s *Server) coffeeIndex(id int) int {
+	return slices.IndexFunc(s.coffees, func(c hashicups.Coffee) bool { return c.ID == id })
+}
+
+func (s *Server) orderIndex(id int) int {
+	return slices.IndexFunc(s.orders, func(o hashicups.Order) bool { return o.ID == id })
+}
+
+func (s *Server) now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+func orderItemIndex(order hashicups.Order, coffeeID int) int {
+	return slices.IndexFunc(order.Items, func(item hashicups.OrderItem) bool { return item.Coffee.ID == coffeeID })
+}
+
+func cloneCoffee(coffee hashicups.Coffee) hashicups.Coffee {
+	coffee.Ingredient = slices.Clone(coffee.Ingredient)
+	return coffee
+}
+
+func pathID(w http.ResponseWriter, r *http.Request, name string) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue(name))
+	if err != nil {
+		http.Error(w, "Invalid "+name+": "+err.Error(), http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
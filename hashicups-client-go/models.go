@@ -1,9 +1,21 @@
 package hashicups
 
+import "time"
+
 // Order -
 type Order struct {
-	ID    int         `json:"id,omitempty"`
-	Items []OrderItem `json:"items,omitempty"`
+	ID        int         `json:"id,omitempty"`
+	Items     []OrderItem `json:"items,omitempty"`
+	CreatedAt *time.Time  `json:"created_at,omitempty"`
+	UpdatedAt *time.Time  `json:"updated_at,omitempty"`
+}
+
+// OrderFilter - Narrows the orders returned by GetOrders. Zero values match
+// every order.
+type OrderFilter struct {
+	CoffeeID int
+	From     time.Time
+	To       time.Time
 }
 
 // OrderItem -
@@ -20,15 +32,17 @@ type Coffee struct {
 	Collection  string       `json:"collection"`
 	Origin      string       `json:"origin"`
 	Description string       `json:"description"`
-	Price       float64      `json:"price"`
+	Color       string       `json:"color"`
+	Price       Money        `json:"price"`
+	Currency    string       `json:"currency,omitempty"`
 	Image       string       `json:"image"`
 	Ingredient  []Ingredient `json:"ingredients"`
 }
 
 // Ingredient -
 type Ingredient struct {
-	ID       int    `json:"ingredient_id"`
-	Name     string `json:"name"`
-	Quantity int    `json:"quantity"`
-	Unit     string `json:"unit"`
+	ID       int     `json:"ingredient_id"`
+	Name     string  `json:"name"`
+	Quantity float64 `json:"quantity"`
+	Unit     string  `json:"unit"`
 }
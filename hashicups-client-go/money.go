@@ -0,0 +1,142 @@
+package hashicups
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// ErrInvalidMoney - Returned when an amount is not a decimal number with at most two decimal places
+var ErrInvalidMoney = errors.New("invalid money amount")
+
+// Money - An exact amount in cents. It is encoded as a plain JSON number,
+// such as 3.5, so it stays compatible with the HashiCups API.
+type Money struct {
+	cents int64
+}
+
+// MoneyFromCents returns the amount of the given number of cents.
+func MoneyFromCents(cents int64) Money {
+	return Money{cents: cents}
+}
+
+// MoneyFromUnits returns the amount of the given number of whole currency
+// units.
+func MoneyFromUnits(units int64) Money {
+	return Money{cents: units * 100}
+}
+
+// ParseMoney parses a decimal amount such as "3.50", "3.5" or "350". Amounts
+// with fractions of a cent are rejected rather than rounded.
+func ParseMoney(s string) (Money, error) {
+	cents, err := parseCents(s)
+	if err != nil {
+		return Money{}, err
+	}
+	if !cents.IsInt() {
+		return Money{}, fmt.Errorf("%w: %q has more than two decimal places", ErrInvalidMoney, s)
+	}
+	return moneyFromRat(s, cents)
+}
+
+// parseCents parses a decimal amount into an exact number of cents.
+func parseCents(s string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q is not a number", ErrInvalidMoney, s)
+	}
+	return r.Mul(r, big.NewRat(100, 1)), nil
+}
+
+// moneyFromRat returns the whole number of cents, parsed from s.
+func moneyFromRat(s string, cents *big.Rat) (Money, error) {
+	if !cents.Num().IsInt64() {
+		return Money{}, fmt.Errorf("%w: %q is out of range", ErrInvalidMoney, s)
+	}
+	return Money{cents: cents.Num().Int64()}, nil
+}
+
+// roundCents rounds a number of cents to a whole cent, halves away from
+// zero.
+func roundCents(cents *big.Rat) *big.Rat {
+	if cents.IsInt() {
+		return cents
+	}
+
+	num := new(big.Int).Abs(cents.Num())
+	quo, rem := new(big.Int).QuoRem(num, cents.Denom(), new(big.Int))
+	if rem.Lsh(rem, 1).Cmp(cents.Denom()) >= 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	if cents.Sign() < 0 {
+		quo.Neg(quo)
+	}
+	return new(big.Rat).SetInt(quo)
+}
+
+// Cents returns the amount in cents.
+func (m Money) Cents() int64 {
+	return m.cents
+}
+
+// Float64 returns the amount in whole currency units. Use it for display
+// only, sums should be computed with Add and Mul.
+func (m Money) Float64() float64 {
+	return float64(m.cents) / 100
+}
+
+// Add returns the sum of m and o.
+func (m Money) Add(o Money) Money {
+	return Money{cents: m.cents + o.cents}
+}
+
+// Mul returns m times n.
+func (m Money) Mul(n int64) Money {
+	return Money{cents: m.cents * n}
+}
+
+// Cmp compares m and o and returns -1, 0 or +1.
+func (m Money) Cmp(o Money) int {
+	switch {
+	case m.cents < o.cents:
+		return -1
+	case m.cents > o.cents:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// String formats the amount with two decimal places, such as "3.50".
+func (m Money) String() string {
+	sign, cents := "", m.cents
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return sign + strconv.FormatInt(cents/100, 10) + fmt.Sprintf(".%02d", cents%100)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON decodes an amount sent by the API. Unlike ParseMoney it
+// rounds fractions of a cent, so a single odd price does not fail the decode
+// of a whole response.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	cents, err := parseCents(string(b))
+	if err != nil {
+		return err
+	}
+	parsed, err := moneyFromRat(string(b), roundCents(cents))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
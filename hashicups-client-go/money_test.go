@@ -0,0 +1,99 @@
+package hashicups_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		input string
+		cents int64
+		str   string
+		err   bool
+	}{
+		{input: "3.50", cents: 350, str: "3.50"},
+		{input: "3.5", cents: 350, str: "3.50"},
+		{input: "200", cents: 20000, str: "200.00"},
+		{input: "0.07", cents: 7, str: "0.07"},
+		{input: "-1.25", cents: -125, str: "-1.25"},
+		{input: "1e2", cents: 10000, str: "100.00"},
+		{input: "3.505", err: true},
+		{input: "three", err: true},
+		{input: "", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			m, err := hashicups.ParseMoney(test.input)
+			if test.err {
+				if !errors.Is(err, hashicups.ErrInvalidMoney) {
+					t.Fatalf("expected ErrInvalidMoney, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.Cents() != test.cents || m.String() != test.str {
+				t.Errorf("expected %d cents formatted as %s, got %d and %s", test.cents, test.str, m.Cents(), m)
+			}
+		})
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	// 0.1 + 0.2 is not 0.3 with float64.
+	total := hashicups.MoneyFromCents(10).Add(hashicups.MoneyFromCents(20))
+	if total != hashicups.MoneyFromCents(30) {
+		t.Errorf("expected 0.30, got %s", total)
+	}
+
+	total = hashicups.MoneyFromCents(350).Mul(3)
+	if total.String() != "10.50" || total.Float64() != 10.5 {
+		t.Errorf("expected 10.50, got %s", total)
+	}
+
+	if hashicups.MoneyFromUnits(1).Cmp(hashicups.MoneyFromCents(99)) != 1 {
+		t.Error("expected 1.00 to be more than 0.99")
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	coffee := hashicups.Coffee{}
+	if err := json.Unmarshal([]byte(`{"name": "Latte", "price": 3.5}`), &coffee); err != nil {
+		t.Fatal(err)
+	}
+	if coffee.Price != hashicups.MoneyFromCents(350) {
+		t.Errorf("expected 3.50, got %s", coffee.Price)
+	}
+
+	b, err := json.Marshal(map[string]hashicups.Money{"price": coffee.Price})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"price":3.50}` {
+		t.Errorf("unexpected encoding: %s", b)
+	}
+
+	// Amounts from the API are rounded to the cent rather than rejected
+	for body, cents := range map[string]int64{
+		`{"price": 0.333}`:  33,
+		`{"price": 2.005}`:  201,
+		`{"price": -2.005}`: -201,
+		`{"price": 2.0049}`: 200,
+	} {
+		if err := json.Unmarshal([]byte(body), &coffee); err != nil {
+			t.Errorf("%s: %v", body, err)
+		} else if coffee.Price != hashicups.MoneyFromCents(cents) {
+			t.Errorf("%s: expected %s, got %s", body, hashicups.MoneyFromCents(cents), coffee.Price)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(`{"price": "free"}`), &coffee); err == nil {
+		t.Error("expected an error for a price that is not a number")
+	}
+}
@@ -5,23 +5,80 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
-// GetOrder - Returns a specifc order
-func (c *Client) GetOrder(orderID string) (*Order, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/orders/%s", c.HostURL, orderID), nil)
+// GetOrders - Returns the orders of the signed in user matching filter.
+// Orders without a creation timestamp never match a date range.
+func (c *Client) GetOrders(filter OrderFilter) ([]Order, error) {
+	query := url.Values{}
+	if filter.CoffeeID != 0 {
+		query.Set("coffee_id", strconv.Itoa(filter.CoffeeID))
+	}
+	if !filter.From.IsZero() {
+		query.Set("from", filter.From.Format(time.RFC3339))
+	}
+	if !filter.To.IsZero() {
+		query.Set("to", filter.To.Format(time.RFC3339))
+	}
+
+	u := fmt.Sprintf("%s/orders", c.HostURL)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	body, err := c.doRequest(req)
+	// The server may not support filtering, so apply the filter here too.
+	orders := []Order{}
+	err = doJSONList(c, req, func(order Order) error {
+		if filter.matches(order) {
+			orders = append(orders, order)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func (f OrderFilter) matches(order Order) bool {
+	if f.CoffeeID != 0 && !slices.ContainsFunc(order.Items, func(item OrderItem) bool { return item.Coffee.ID == f.CoffeeID }) {
+		return false
+	}
+	if f.From.IsZero() && f.To.IsZero() {
+		return true
+	}
+	if order.CreatedAt == nil {
+		return false
+	}
+	if !f.From.IsZero() && order.CreatedAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && order.CreatedAt.After(f.To) {
+		return false
+	}
+	return true
+}
+
+// GetOrder - Returns a specifc order
+func (c *Client) GetOrder(orderID string) (*Order, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/orders/%s", c.HostURL, orderID), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	order := Order{}
-	err = json.Unmarshal(body, &order)
+	err = c.doJSON(req, &order)
 	if err != nil {
 		return nil, err
 	}
@@ -41,13 +98,8 @@ func (c *Client) CreateOrder(orderItems []OrderItem) (*Order, error) {
 		return nil, err
 	}
 
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
-
 	order := Order{}
-	err = json.Unmarshal(body, &order)
+	err = c.doJSON(req, &order)
 	if err != nil {
 		return nil, err
 	}
@@ -67,13 +119,48 @@ func (c *Client) UpdateOrder(orderID string, orderItems []OrderItem) (*Order, er
 		return nil, err
 	}
 
+	order := Order{}
+	err = c.doJSON(req, &order)
+	if err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// DeleteOrder - Deletes an order
+func (c *Client) DeleteOrder(orderID string) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/orders/%s", c.HostURL, orderID), nil)
+	if err != nil {
+		return err
+	}
+
 	body, err := c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if string(body) != "Deleted order" {
+		return errors.New(string(body))
+	}
+
+	return nil
+}
+
+// AddOrderItem - Adds a coffee to an existing order
+func (c *Client) AddOrderItem(orderID string, item OrderItem) (*Order, error) {
+	rb, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/orders/%s/items", c.HostURL, orderID), strings.NewReader(string(rb)))
 	if err != nil {
 		return nil, err
 	}
 
 	order := Order{}
-	err = json.Unmarshal(body, &order)
+	err = c.doJSON(req, &order)
 	if err != nil {
 		return nil, err
 	}
@@ -81,9 +168,30 @@ func (c *Client) UpdateOrder(orderID string, orderItems []OrderItem) (*Order, er
 	return &order, nil
 }
 
-// DeleteOrder - Deletes an order
-func (c *Client) DeleteOrder(orderID string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/orders/%s", c.HostURL, orderID), nil)
+// UpdateOrderItem - Changes the quantity of a coffee in an order
+func (c *Client) UpdateOrderItem(orderID string, item OrderItem) (*Order, error) {
+	rb, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/orders/%s/items/%d", c.HostURL, orderID, item.Coffee.ID), strings.NewReader(string(rb)))
+	if err != nil {
+		return nil, err
+	}
+
+	order := Order{}
+	err = c.doJSON(req, &order)
+	if err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// DeleteOrderItem - Removes a coffee from an order
+func (c *Client) DeleteOrderItem(orderID string, coffeeID int) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/orders/%s/items/%d", c.HostURL, orderID, coffeeID), nil)
 	if err != nil {
 		return err
 	}
@@ -93,7 +201,7 @@ func (c *Client) DeleteOrder(orderID string) error {
 		return err
 	}
 
-	if string(body) != "Deleted order" {
+	if string(body) != "Deleted order item" {
 		return errors.New(string(body))
 	}
 
@@ -0,0 +1,133 @@
+package hashicups_test
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp-demoapp/hashicups-client-go/hashicupstest"
+)
+
+func TestGetOrders(t *testing.T) {
+	s := hashicupstest.NewServer()
+	defer s.Close()
+	client := newTestClient(t, s)
+
+	orders, err := client.GetOrders(hashicups.OrderFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 0 {
+		t.Fatalf("expected no orders, got %d", len(orders))
+	}
+
+	first, err := client.CreateOrder([]hashicups.OrderItem{{Coffee: hashicups.Coffee{ID: 1}, Quantity: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := client.CreateOrder([]hashicups.OrderItem{{Coffee: hashicups.Coffee{ID: 2}, Quantity: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetOrderCreatedAt(first.ID, time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC))
+
+	tests := map[string]struct {
+		filter   hashicups.OrderFilter
+		expected []int
+	}{
+		"all": {
+			filter:   hashicups.OrderFilter{},
+			expected: []int{first.ID, second.ID},
+		},
+		"coffee": {
+			filter:   hashicups.OrderFilter{CoffeeID: 2},
+			expected: []int{second.ID},
+		},
+		"unknown coffee": {
+			filter:   hashicups.OrderFilter{CoffeeID: 42},
+			expected: []int{},
+		},
+		"from": {
+			filter:   hashicups.OrderFilter{From: time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)},
+			expected: []int{second.ID},
+		},
+		"range": {
+			filter: hashicups.OrderFilter{
+				From: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC),
+			},
+			expected: []int{first.ID},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			orders, err := client.GetOrders(test.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := []int{}
+			for _, order := range orders {
+				ids = append(ids, order.ID)
+			}
+			if !slices.Equal(ids, test.expected) {
+				t.Fatalf("expected orders %v, got %v", test.expected, ids)
+			}
+		})
+	}
+
+	if orders, _ := client.GetOrders(hashicups.OrderFilter{}); orders[0].Items[0].Coffee.Name != "HCP Aeropress" {
+		t.Errorf("expected order items to carry coffee details, got %+v", orders[0].Items)
+	}
+}
+
+func TestOrderItems(t *testing.T) {
+	s := hashicupstest.NewServer()
+	defer s.Close()
+	client := newTestClient(t, s)
+
+	order, err := client.CreateOrder([]hashicups.OrderItem{{Coffee: hashicups.Coffee{ID: 1}, Quantity: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderID := strconv.Itoa(order.ID)
+
+	order, err = client.AddOrderItem(orderID, hashicups.OrderItem{Coffee: hashicups.Coffee{ID: 2}, Quantity: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(order.Items) != 2 || order.Items[1].Coffee.Name != "Packer Spiced Latte" {
+		t.Fatalf("expected added item with coffee details, got %+v", order.Items)
+	}
+
+	if _, err := client.AddOrderItem(orderID, hashicups.OrderItem{Coffee: hashicups.Coffee{ID: 2}, Quantity: 1}); err == nil {
+		t.Error("expected adding a coffee twice to fail")
+	}
+
+	order, err = client.UpdateOrderItem(orderID, hashicups.OrderItem{Coffee: hashicups.Coffee{ID: 2}, Quantity: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Items[0].Quantity != 2 || order.Items[1].Quantity != 5 {
+		t.Fatalf("expected only the second item to change, got %+v", order.Items)
+	}
+
+	if err := client.DeleteOrderItem(orderID, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	order, err = client.GetOrder(orderID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(order.Items) != 1 || order.Items[0].Coffee.ID != 2 || order.Items[0].Quantity != 5 {
+		t.Fatalf("expected only coffee 2 to remain, got %+v", order.Items)
+	}
+
+	if err := client.DeleteOrderItem(orderID, 1); err == nil {
+		t.Error("expected deleting a removed item to fail")
+	}
+}
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &coffeeDataSource{}
+	_ datasource.DataSourceWithConfigure      = &coffeeDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &coffeeDataSource{}
+)
+
+// NewCoffeeDataSource is a helper function to simplify the provider implementation.
+func NewCoffeeDataSource() datasource.DataSource {
+	return &coffeeDataSource{}
+}
+
+// coffeeDataSource is the data source implementation.
+type coffeeDataSource struct {
+	client *hashicups.Client
+}
+
+// Metadata returns the data source type name.
+func (d *coffeeDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_coffee"
+}
+
+// Schema defines the schema for the data source.
+func (d *coffeeDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	// The coffee is looked up by either of its id or name
+	attributes := coffeeDataSourceAttributes()
+	attributes["id"] = schema.Int64Attribute{
+		Description: "Numeric identifier of the coffee. Exactly one of id or name must be set.",
+		Optional:    true,
+		Computed:    true,
+	}
+	attributes["name"] = schema.StringAttribute{
+		Description: "Product name of the coffee. Exactly one of id or name must be set. " +
+			"The name must match exactly one coffee.",
+		Optional: true,
+		Computed: true,
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Fetches a single coffee by its identifier or name.",
+		Attributes:  attributes,
+	}
+}
+
+// ValidateConfig checks that the coffee is looked up either by ID or by name.
+func (d *coffeeDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config coffeeModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case config.ID.IsNull() && config.Name.IsNull():
+		resp.Diagnostics.AddError(
+			"Missing Coffee Lookup",
+			"Set either the id or the name of the coffee to look up.",
+		)
+	case !config.ID.IsNull() && !config.Name.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Conflicting Coffee Lookup",
+			"Set only one of the id or the name of the coffee to look up, not both.",
+		)
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *coffeeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state coffeeModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Resolve a name to the ID of the only coffee with that name
+	coffeeID := strconv.FormatInt(state.ID.ValueInt64(), 10)
+	if state.ID.IsNull() {
+		id, ok := d.coffeeIDByName(state.Name.ValueString(), resp)
+		if !ok {
+			return
+		}
+		coffeeID = strconv.Itoa(id)
+	}
+
+	coffee, err := d.client.GetCoffee(coffeeID)
+	if errors.Is(err, hashicups.ErrNotFound) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Coffee Not Found",
+			fmt.Sprintf("The HashiCups catalog has no coffee with ID %s.", coffeeID),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read HashiCups Coffee",
+			"Could not read HashiCups coffee ID "+coffeeID+": "+err.Error(),
+		)
+		return
+	}
+
+	ingredients, err := d.client.GetCoffeeIngredients(coffeeID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read HashiCups Coffee Ingredients",
+			"Could not read the ingredients of HashiCups coffee ID "+coffeeID+": "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to model
+	state = newCoffeeModel(*coffee)
+	state.Ingredients = coffeeIngredientsValue(ingredients)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// coffeeIDByName returns the ID of the only coffee with the given name. It
+// adds an error and returns false when no coffee or several coffees have it.
+func (d *coffeeDataSource) coffeeIDByName(name string, resp *datasource.ReadResponse) (int, bool) {
+	coffees, err := d.client.GetCoffees()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read HashiCups Coffees",
+			"Could not read the HashiCups catalog to look up the coffee name: "+err.Error(),
+		)
+		return 0, false
+	}
+
+	var ids []string
+	var id int
+	for _, coffee := range coffees {
+		if coffee.Name == name {
+			id = coffee.ID
+			ids = append(ids, strconv.Itoa(coffee.ID))
+		}
+	}
+
+	switch len(ids) {
+	case 1:
+		return id, true
+	case 0:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Coffee Not Found",
+			fmt.Sprintf("The HashiCups catalog has no coffee named %q.", name),
+		)
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Ambiguous Coffee Name",
+			fmt.Sprintf("The HashiCups catalog has %d coffees named %q, with IDs %s. Look the coffee up by id instead.",
+				len(ids), name, strings.Join(ids, ", ")),
+		)
+	}
+	return 0, false
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *coffeeDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hashicups.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *hashicups.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
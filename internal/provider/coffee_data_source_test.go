@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp-demoapp/hashicups-client-go/hashicupstest"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// coffeeLookup returns a data source configuration that looks a coffee up
+// by id or name.
+func coffeeLookup(id types.Int64, name types.String) coffeeModel {
+	return coffeeModel{
+		ID:          id,
+		Name:        name,
+		Teaser:      types.StringNull(),
+		Collection:  types.StringNull(),
+		Origin:      types.StringNull(),
+		Description: types.StringNull(),
+		Color:       types.StringNull(),
+		Price:       types.Float64Null(),
+		Currency:    types.StringNull(),
+		Image:       types.StringNull(),
+		Ingredients: types.ListNull(coffeeIngredientsType.ElemType),
+	}
+}
+
+func TestCoffeeDataSourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	d := NewCoffeeDataSource()
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	tests := map[string]struct {
+		config  coffeeModel
+		summary string
+	}{
+		"by id":             {config: coffeeLookup(types.Int64Value(1), types.StringNull())},
+		"by name":           {config: coffeeLookup(types.Int64Null(), types.StringValue("Vaulatte"))},
+		"known after apply": {config: coffeeLookup(types.Int64Unknown(), types.StringNull())},
+		"missing":           {config: coffeeLookup(types.Int64Null(), types.StringNull()), summary: "Missing Coffee Lookup"},
+		"conflicting": {
+			config:  coffeeLookup(types.Int64Value(1), types.StringValue("Vaulatte")),
+			summary: "Conflicting Coffee Lookup",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := &datasource.ValidateConfigResponse{}
+			d.(datasource.DataSourceWithValidateConfig).ValidateConfig(ctx, datasource.ValidateConfigRequest{
+				Config: testConfig(t, tfsdk.Config{Schema: schemaResp.Schema}, test.config),
+			}, resp)
+
+			errs := resp.Diagnostics.Errors()
+			switch {
+			case test.summary == "" && len(errs) != 0:
+				t.Errorf("expected no errors, got %v", errs)
+			case test.summary != "" && (len(errs) != 1 || errs[0].Summary() != test.summary):
+				t.Errorf("expected a %q error, got %v", test.summary, errs)
+			}
+		})
+	}
+}
+
+func TestCoffeeDataSourceRead(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.AddCoffee(hashicups.Coffee{Name: "Vaulatte", Price: hashicups.MoneyFromUnits(250)})
+	coffee, _ := server.Coffee(1)
+
+	for name, config := range map[string]coffeeModel{
+		"by id":   coffeeLookup(types.Int64Value(1), types.StringNull()),
+		"by name": coffeeLookup(types.Int64Null(), types.StringValue("HCP Aeropress")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			var state coffeeModel
+			readDataSource(t, &coffeeDataSource{client: client}, config, &state)
+
+			if state.ID.ValueInt64() != 1 || state.Name.ValueString() != "HCP Aeropress" {
+				t.Errorf("expected coffee 1 HCP Aeropress, got %s %s", state.ID, state.Name)
+			}
+			if state.Collection.ValueString() != "Foundations" || state.Origin.ValueString() != "Summer 2020" {
+				t.Errorf("expected collection Foundations from Summer 2020, got %s from %s", state.Collection, state.Origin)
+			}
+			if state.Price.ValueFloat64() != 200 || state.Currency.ValueString() != defaultCurrency {
+				t.Errorf("expected price 200 %s, got %s %s", defaultCurrency, state.Price, state.Currency)
+			}
+			if !state.Ingredients.Equal(coffeeIngredientsValue(coffee.Ingredient)) {
+				t.Errorf("expected ingredients %s, got %s", coffeeIngredientsValue(coffee.Ingredient), state.Ingredients)
+			}
+		})
+	}
+
+	for name, test := range map[string]struct {
+		config  coffeeModel
+		summary string
+		detail  string
+	}{
+		"unknown id": {
+			config:  coffeeLookup(types.Int64Value(99), types.StringNull()),
+			summary: "Coffee Not Found",
+			detail:  "The HashiCups catalog has no coffee with ID 99.",
+		},
+		"unknown name": {
+			config:  coffeeLookup(types.Int64Null(), types.StringValue("Decaf")),
+			summary: "Coffee Not Found",
+			detail:  `The HashiCups catalog has no coffee named "Decaf".`,
+		},
+		"ambiguous name": {
+			config:  coffeeLookup(types.Int64Null(), types.StringValue("Vaulatte")),
+			summary: "Ambiguous Coffee Name",
+			detail:  `The HashiCups catalog has 2 coffees named "Vaulatte", with IDs 3, 10. Look the coffee up by id instead.`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := readDataSourceResponse(t, &coffeeDataSource{client: client}, test.config)
+
+			errs := resp.Diagnostics.Errors()
+			if len(errs) != 1 || errs[0].Summary() != test.summary || errs[0].Detail() != test.detail {
+				t.Errorf("expected %q error %q, got %v", test.summary, test.detail, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestAccCoffeeDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "hashicups_coffee" "by_id" {
+  id = 1
+}
+
+data "hashicups_coffee" "by_name" {
+  name = "HCP Aeropress"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify all attributes are set
+					resource.TestCheckResourceAttr("data.hashicups_coffee.by_id", "name", "HCP Aeropress"),
+					resource.TestCheckResourceAttr("data.hashicups_coffee.by_id", "teaser", "Automation in a cup"),
+					resource.TestCheckResourceAttr("data.hashicups_coffee.by_id", "collection", "Foundations"),
+					resource.TestCheckResourceAttr("data.hashicups_coffee.by_id", "origin", "Summer 2020"),
+					resource.TestCheckResourceAttr("data.hashicups_coffee.by_id", "description", ""),
+					resource.TestCheckResourceAttr("data.hashicups_coffee.by_id", "price", "200"),
+					resource.TestCheckResourceAttr("data.hashicups_coffee.by_id", "image", "/hashicorp.png"),
+					resource.TestCheckResourceAttr("data.hashicups_coffee.by_id", "ingredients.#", "1"),
+					resource.TestCheckResourceAttr("data.hashicups_coffee.by_id", "ingredients.0.name", "Espresso"),
+					// Verify the lookup by name finds the same coffee
+					resource.TestCheckResourceAttr("data.hashicups_coffee.by_name", "id", "1"),
+					resource.TestCheckResourceAttrPair("data.hashicups_coffee.by_name", "teaser", "data.hashicups_coffee.by_id", "teaser"),
+				),
+			},
+		},
+	})
+}
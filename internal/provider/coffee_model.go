@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// coffeeModel maps coffee schema data. It is shared by the data sources and
+// the order items, so every coffee field is exposed the same way.
+type coffeeModel struct {
+	ID          types.Int64   `tfsdk:"id"`
+	Name        types.String  `tfsdk:"name"`
+	Teaser      types.String  `tfsdk:"teaser"`
+	Collection  types.String  `tfsdk:"collection"`
+	Origin      types.String  `tfsdk:"origin"`
+	Description types.String  `tfsdk:"description"`
+	Color       types.String  `tfsdk:"color"`
+	Price       types.Float64 `tfsdk:"price"`
+	Currency    types.String  `tfsdk:"currency"`
+	Image       types.String  `tfsdk:"image"`
+	Ingredients types.List    `tfsdk:"ingredients"`
+}
+
+// coffeeAttrTypes are the attribute types of coffeeModel. The data source
+// and resource schemas of a coffee are both built from them, so a new coffee
+// field is declared here and in coffeeAttributeDescriptions only.
+var coffeeAttrTypes = map[string]attr.Type{
+	"id":          types.Int64Type,
+	"name":        types.StringType,
+	"teaser":      types.StringType,
+	"collection":  types.StringType,
+	"origin":      types.StringType,
+	"description": types.StringType,
+	"color":       types.StringType,
+	"price":       types.Float64Type,
+	"currency":    types.StringType,
+	"image":       types.StringType,
+	"ingredients": coffeeIngredientsType,
+}
+
+// coffeeAttributeDescriptions describe the attributes of coffeeModel, keyed
+// by attribute path.
+var coffeeAttributeDescriptions = map[string]string{
+	"id":                   "Numeric identifier of the coffee.",
+	"name":                 "Product name of the coffee.",
+	"teaser":               "Fun tagline for the coffee.",
+	"collection":           "Collection the coffee belongs to.",
+	"origin":               "Origin of the coffee.",
+	"description":          "Product description of the coffee.",
+	"color":                "Hex color code of the coffee.",
+	"price":                "Suggested cost of the coffee.",
+	"currency":             "ISO 4217 code of the currency of the price.",
+	"image":                "URI for an image of the coffee.",
+	"ingredients":          "List of ingredients in the coffee.",
+	"ingredients.id":       "Numeric identifier of the coffee ingredient.",
+	"ingredients.name":     "Name of the coffee ingredient.",
+	"ingredients.quantity": "Quantity of the coffee ingredient.",
+	"ingredients.unit":     "Unit of the quantity of the coffee ingredient.",
+}
+
+// coffeeIngredientAttrTypes are the attribute types of a coffee ingredient
+// in coffeeModel.
+var coffeeIngredientAttrTypes = map[string]attr.Type{
+	"id":       types.Int64Type,
+	"name":     types.StringType,
+	"quantity": types.Float64Type,
+	"unit":     types.StringType,
+}
+
+// coffeeIngredientsType is the type of the ingredients in coffeeModel.
+var coffeeIngredientsType = types.ListType{ElemType: types.ObjectType{AttrTypes: coffeeIngredientAttrTypes}}
+
+// newCoffeeModel maps a coffee returned by the API to the model, ingredients
+// included.
+func newCoffeeModel(coffee hashicups.Coffee) coffeeModel {
+	currency := coffee.Currency
+	if currency == "" {
+		currency = defaultCurrency
+	}
+
+	return coffeeModel{
+		ID:          types.Int64Value(int64(coffee.ID)),
+		Name:        types.StringValue(coffee.Name),
+		Teaser:      types.StringValue(coffee.Teaser),
+		Collection:  types.StringValue(coffee.Collection),
+		Origin:      types.StringValue(coffee.Origin),
+		Description: types.StringValue(coffee.Description),
+		Color:       types.StringValue(coffee.Color),
+		Price:       types.Float64Value(coffee.Price.Float64()),
+		Currency:    types.StringValue(currency),
+		Image:       types.StringValue(coffee.Image),
+		Ingredients: coffeeIngredientsValue(coffee.Ingredient),
+	}
+}
+
+// coffeeIngredientsValue maps coffee ingredients returned by the API to the
+// ingredients in coffeeModel.
+func coffeeIngredientsValue(ingredients []hashicups.Ingredient) types.List {
+	elements := make([]attr.Value, 0, len(ingredients))
+	for _, ingredient := range ingredients {
+		elements = append(elements, types.ObjectValueMust(coffeeIngredientAttrTypes, map[string]attr.Value{
+			"id":       types.Int64Value(int64(ingredient.ID)),
+			"name":     types.StringValue(ingredient.Name),
+			"quantity": types.Float64Value(ingredient.Quantity),
+			"unit":     types.StringValue(ingredient.Unit),
+		}))
+	}
+	return types.ListValueMust(coffeeIngredientsType.ElemType, elements)
+}
+
+// coffeeDataSourceAttributes returns the data source schema attributes of
+// coffeeModel, all computed.
+func coffeeDataSourceAttributes() map[string]schema.Attribute {
+	return computedDataSourceAttributes(coffeeAttrTypes, "")
+}
+
+// computedDataSourceAttributes returns computed data source attributes of
+// the given types, described by coffeeAttributeDescriptions under prefix.
+func computedDataSourceAttributes(attrTypes map[string]attr.Type, prefix string) map[string]schema.Attribute {
+	attributes := make(map[string]schema.Attribute, len(attrTypes))
+	for name, attrType := range attrTypes {
+		description := coffeeAttributeDescriptions[prefix+name]
+		switch attrType := attrType.(type) {
+		case basetypes.Int64Type:
+			attributes[name] = schema.Int64Attribute{Description: description, Computed: true}
+		case basetypes.Float64Type:
+			attributes[name] = schema.Float64Attribute{Description: description, Computed: true}
+		case basetypes.StringType:
+			attributes[name] = schema.StringAttribute{Description: description, Computed: true}
+		case basetypes.ListType:
+			if element, ok := attrType.ElemType.(basetypes.ObjectType); ok {
+				attributes[name] = schema.ListNestedAttribute{
+					Description: description,
+					Computed:    true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: computedDataSourceAttributes(element.AttrTypes, prefix+name+"."),
+					},
+				}
+			}
+		}
+	}
+	return attributes
+}
+
+// coffeeResourceAttributes returns the resource schema attributes of
+// coffeeModel, all computed.
+func coffeeResourceAttributes() map[string]resourceschema.Attribute {
+	return computedResourceAttributes(coffeeAttrTypes, "")
+}
+
+// computedResourceAttributes returns computed resource attributes of the
+// given types, described by coffeeAttributeDescriptions under prefix.
+func computedResourceAttributes(attrTypes map[string]attr.Type, prefix string) map[string]resourceschema.Attribute {
+	attributes := make(map[string]resourceschema.Attribute, len(attrTypes))
+	for name, attrType := range attrTypes {
+		description := coffeeAttributeDescriptions[prefix+name]
+		switch attrType := attrType.(type) {
+		case basetypes.Int64Type:
+			attributes[name] = resourceschema.Int64Attribute{Description: description, Computed: true}
+		case basetypes.Float64Type:
+			attributes[name] = resourceschema.Float64Attribute{Description: description, Computed: true}
+		case basetypes.StringType:
+			attributes[name] = resourceschema.StringAttribute{Description: description, Computed: true}
+		case basetypes.ListType:
+			if element, ok := attrType.ElemType.(basetypes.ObjectType); ok {
+				attributes[name] = resourceschema.ListNestedAttribute{
+					Description: description,
+					Computed:    true,
+					NestedObject: resourceschema.NestedAttributeObject{
+						Attributes: computedResourceAttributes(element.AttrTypes, prefix+name+"."),
+					},
+				}
+			}
+		}
+	}
+	return attributes
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestNewCoffeeModel(t *testing.T) {
+	coffee := hashicups.Coffee{
+		ID:          7,
+		Name:        "Connectaccino",
+		Teaser:      "Discover the wonders of our meshy service",
+		Collection:  "Origins",
+		Origin:      "Spring 2014",
+		Description: "Espresso with semi skimmed milk",
+		Color:       "#444",
+		Price:       hashicups.MoneyFromCents(250),
+		Currency:    "EUR",
+		Image:       "/consul.png",
+		Ingredient: []hashicups.Ingredient{
+			{ID: 1, Name: "Espresso", Quantity: 40, Unit: "ml"},
+			{ID: 4, Name: "Semi Skimmed Milk", Quantity: 300, Unit: "ml"},
+		},
+	}
+
+	expected := coffeeModel{
+		ID:          types.Int64Value(7),
+		Name:        types.StringValue("Connectaccino"),
+		Teaser:      types.StringValue("Discover the wonders of our meshy service"),
+		Collection:  types.StringValue("Origins"),
+		Origin:      types.StringValue("Spring 2014"),
+		Description: types.StringValue("Espresso with semi skimmed milk"),
+		Color:       types.StringValue("#444"),
+		Price:       types.Float64Value(2.5),
+		Currency:    types.StringValue("EUR"),
+		Image:       types.StringValue("/consul.png"),
+		Ingredients: types.ListValueMust(coffeeIngredientsType.ElemType, []attr.Value{
+			types.ObjectValueMust(coffeeIngredientAttrTypes, map[string]attr.Value{
+				"id":       types.Int64Value(1),
+				"name":     types.StringValue("Espresso"),
+				"quantity": types.Float64Value(40),
+				"unit":     types.StringValue("ml"),
+			}),
+			types.ObjectValueMust(coffeeIngredientAttrTypes, map[string]attr.Value{
+				"id":       types.Int64Value(4),
+				"name":     types.StringValue("Semi Skimmed Milk"),
+				"quantity": types.Float64Value(300),
+				"unit":     types.StringValue("ml"),
+			}),
+		}),
+	}
+	if got := newCoffeeModel(coffee); !equalCoffee(got, expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+
+	// The API omits the currency of prices in the default currency
+	coffee.Currency = ""
+	coffee.Ingredient = nil
+	got := newCoffeeModel(coffee)
+	if !got.Currency.Equal(types.StringValue(defaultCurrency)) {
+		t.Errorf("expected currency %s, got %s", defaultCurrency, got.Currency)
+	}
+	if got.Ingredients.IsNull() || len(got.Ingredients.Elements()) != 0 {
+		t.Errorf("expected no ingredients, got %s", got.Ingredients)
+	}
+}
+
+// equalCoffee reports whether every attribute of a and b is equal.
+// reflect.DeepEqual would also compare the internals of decoded numbers.
+func equalCoffee(a, b coffeeModel) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	for i := range va.NumField() {
+		if !va.Field(i).Interface().(attr.Value).Equal(vb.Field(i).Interface().(attr.Value)) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCoffeeAttributes(t *testing.T) {
+	ctx := context.Background()
+	expected := types.ObjectType{AttrTypes: coffeeAttrTypes}
+
+	// The model encodes with the attribute types the schemas are built from.
+	if _, diags := types.ObjectValueFrom(ctx, coffeeAttrTypes, coffeeModel{Ingredients: types.ListNull(coffeeIngredientsType.ElemType)}); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	dataSourceResp := &datasource.SchemaResponse{}
+	NewCoffeesDataSource().Schema(ctx, datasource.SchemaRequest{}, dataSourceResp)
+	coffees := dataSourceResp.Schema.Attributes["coffees"].GetType().(basetypes.ListType)
+	if got := coffees.ElemType; !got.Equal(expected) {
+		t.Errorf("data source coffee: expected %s, got %s", expected, got)
+	}
+
+	resourceResp := &resource.SchemaResponse{}
+	NewOrderResource().Schema(ctx, resource.SchemaRequest{}, resourceResp)
+	items := resourceResp.Schema.Attributes["items"].GetType().(basetypes.ListType)
+	if got := items.ElemType.(basetypes.ObjectType).AttrTypes["coffee"]; !got.Equal(expected) {
+		t.Errorf("order item coffee: expected %s, got %s", expected, got)
+	}
+
+	for name := range coffeeAttrTypes {
+		if coffeeAttributeDescriptions[name] == "" {
+			t.Errorf("%s: missing description", name)
+		}
+	}
+	for name := range coffeeIngredientAttrTypes {
+		if coffeeAttributeDescriptions["ingredients."+name] == "" {
+			t.Errorf("ingredients.%s: missing description", name)
+		}
+	}
+}
@@ -2,27 +2,51 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"math/big"
 	"slices"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// on_create_failure values.
+const (
+	onCreateFailureRollback = "rollback"
+	onCreateFailureTaint    = "taint"
+)
+
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &coffeeResource{}
-	_ resource.ResourceWithConfigure   = &coffeeResource{}
-	_ resource.ResourceWithImportState = &coffeeResource{}
+	_ resource.Resource                   = &coffeeResource{}
+	_ resource.ResourceWithConfigure      = &coffeeResource{}
+	_ resource.ResourceWithImportState    = &coffeeResource{}
+	_ resource.ResourceWithUpgradeState   = &coffeeResource{}
+	_ resource.ResourceWithValidateConfig = &coffeeResource{}
 )
 
+// maxTeaserLength is the longest teaser, in characters, the HashiCups menu
+// can display.
+const maxTeaserLength = 100
+
+// defaultCurrency is the currency of coffee prices when none is configured.
+const defaultCurrency = "USD"
+
 // NewcoffeeResource is a helper function to simplify the provider implementation.
 func NewCoffeeResource() resource.Resource {
 	return &coffeeResource{}
@@ -35,22 +59,24 @@ type coffeeResource struct {
 
 // coffeeResourceModel maps the resource schema data.
 type coffeeResourceModel struct {
-	ID          types.String      `tfsdk:"id"`
-	Name        types.String      `tfsdk:"name"`
-	Teaser      types.String      `tfsdk:"teaser"`
-	Collection  types.String      `tfsdk:"collection"`
-	Origin      types.String      `tfsdk:"origin"`
-	Color       types.String      `tfsdk:"color"`
-	Description types.String      `tfsdk:"description"`
-	Price       types.Int64       `tfsdk:"price"`
-	Image       types.String      `tfsdk:"image"`
-	Ingredients []ingredientModel `tfsdk:"ingredients"`
+	ID              types.String               `tfsdk:"id"`
+	Name            types.String               `tfsdk:"name"`
+	Teaser          types.String               `tfsdk:"teaser"`
+	Collection      types.String               `tfsdk:"collection"`
+	Origin          types.String               `tfsdk:"origin"`
+	Color           types.String               `tfsdk:"color"`
+	Description     types.String               `tfsdk:"description"`
+	Price           types.Number               `tfsdk:"price"`
+	Currency        types.String               `tfsdk:"currency"`
+	Image           types.String               `tfsdk:"image"`
+	Ingredients     map[string]ingredientModel `tfsdk:"ingredients"`
+	OnCreateFailure types.String               `tfsdk:"on_create_failure"`
 }
 
-// ingredientModel maps the nested ingredient schema data.
+// ingredientModel maps the nested ingredient schema data. The ingredient
+// name is the map key.
 type ingredientModel struct {
 	IngredientID types.Int64   `tfsdk:"ingredient_id"`
-	Name         types.String  `tfsdk:"name"`
 	Quantity     types.Float64 `tfsdk:"quantity"`
 	Unit         types.String  `tfsdk:"unit"`
 }
@@ -64,6 +90,7 @@ func (r *coffeeResource) Metadata(_ context.Context, req resource.MetadataReques
 func (r *coffeeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a coffee.",
+		Version:     2,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Numeric identifier of the coffee.",
@@ -77,7 +104,7 @@ func (r *coffeeResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Required:    true,
 			},
 			"teaser": schema.StringAttribute{
-				Description: "Short teaser text for the coffee.",
+				Description: "Short teaser text for the coffee, at most 100 characters.",
 				Optional:    true,
 			},
 			"collection": schema.StringAttribute{
@@ -89,41 +116,74 @@ func (r *coffeeResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 			},
 			"color": schema.StringAttribute{
-				Description: "Color code associated with the coffee.",
+				Description: "Hex color code associated with the coffee, such as `#444` or `#0F3B5C`.",
 				Optional:    true,
+				Validators: []validator.String{
+					hexColor(),
+				},
 			},
 			"description": schema.StringAttribute{
 				Description: "Detailed description of the coffee.",
 				Optional:    true,
 			},
-			"price": schema.Int64Attribute{
-				Description: "Price of the coffee in USD.",
+			"price": schema.NumberAttribute{
+				Description: "Price of the coffee, with at most two decimal places.",
 				Required:    true,
+				Validators: []validator.Number{
+					money(),
+				},
+			},
+			"currency": schema.StringAttribute{
+				Description: "Three letter code of the currency of the price. Defaults to `USD`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(defaultCurrency),
+				Validators: []validator.String{
+					currencyCode(),
+				},
 			},
 			"image": schema.StringAttribute{
 				Description: "URL or path to the coffee image.",
 				Optional:    true,
 			},
-			"ingredients": schema.ListNestedAttribute{
-				Description: "List of ingredients in the coffee.",
+			"on_create_failure": schema.StringAttribute{
+				Description: "What to do with the coffee when adding its ingredients fails during creation. " +
+					"`rollback` deletes the coffee again, `taint` keeps it in state marked for replacement. Defaults to `taint`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(onCreateFailureTaint),
+				Validators: []validator.String{
+					stringOneOf(onCreateFailureRollback, onCreateFailureTaint),
+				},
+			},
+			"ingredients": schema.MapNestedAttribute{
+				Description: "Ingredients of the coffee, keyed by ingredient name.",
 				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					useEquivalentIngredientState(),
+				},
 				NestedObject: schema.NestedAttributeObject{
+					CustomType: newIngredientType(),
 					Attributes: map[string]schema.Attribute{
 						"ingredient_id": schema.Int64Attribute{
 							Description: "Identifier of the ingredient.",
 							Computed:    true,
-						},
-						"name": schema.StringAttribute{
-							Description: "Name of the ingredient.",
-							Optional:    true,
+							PlanModifiers: []planmodifier.Int64{
+								int64planmodifier.UseStateForUnknown(),
+							},
 						},
 						"quantity": schema.Float64Attribute{
 							Description: "Quantity of the ingredient.",
 							Required:    true,
 						},
 						"unit": schema.StringAttribute{
-							Description: "Unit of measurement for the ingredient.",
-							Required:    true,
+							Description: "Unit of measurement for the ingredient. Volume (`ml`, `cl`, `dl`, `l`, `tsp`, `tbsp`, `floz`, `cup`), " +
+								"mass (`mg`, `g`, `kg`, `oz`) or count (`piece`, `shot`, `pump`, `scoop`, `cube`). " +
+								"Quantities in convertible units, such as 10 dl and 1000 ml, are considered equal.",
+							Required: true,
+							Validators: []validator.String{
+								stringOneOf(unitNames()...),
+							},
 						},
 					},
 				},
@@ -132,6 +192,64 @@ func (r *coffeeResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 	}
 }
 
+// ValidateConfig rejects configurations the API would fail on, before any
+// coffee is created.
+func (r *coffeeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var price types.Number
+	var teaser types.String
+	var ingredients types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("price"), &price)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("teaser"), &teaser)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ingredients"), &ingredients)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !price.IsNull() && !price.IsUnknown() && price.ValueBigFloat().Sign() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("price"),
+			"Invalid Coffee Price",
+			fmt.Sprintf("The price of a coffee cannot be negative, got: %s", price.ValueBigFloat().Text('f', -1)),
+		)
+	}
+
+	if !teaser.IsNull() && !teaser.IsUnknown() && utf8.RuneCountInString(teaser.ValueString()) > maxTeaserLength {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("teaser"),
+			"Invalid Coffee Teaser",
+			fmt.Sprintf("The teaser can be at most %d characters long, got %d.", maxTeaserLength, utf8.RuneCountInString(teaser.ValueString())),
+		)
+	}
+
+	if ingredients.IsNull() || ingredients.IsUnknown() {
+		return
+	}
+
+	// Names that only differ in case or surrounding spaces describe the
+	// same ingredient.
+	seen := map[string]string{}
+	for _, name := range slices.Sorted(maps.Keys(ingredients.Elements())) {
+		normalized := strings.ToLower(strings.TrimSpace(name))
+		if normalized == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ingredients").AtMapKey(name),
+				"Invalid Ingredient Name",
+				"Ingredient names cannot be empty.",
+			)
+			continue
+		}
+		if other, ok := seen[normalized]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ingredients").AtMapKey(name),
+				"Duplicate Ingredient",
+				fmt.Sprintf("Ingredient %q is the same as ingredient %q. Each ingredient can only be listed once.", name, other),
+			)
+			continue
+		}
+		seen[normalized] = name
+	}
+}
+
 // Create a new resource.
 func (r *coffeeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
@@ -142,35 +260,37 @@ func (r *coffeeResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	price, err := moneyFromNumber(plan.Price)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("price"), "Invalid Coffee Price", err.Error())
+		return
+	}
+
 	hashiCoffee := hashicups.Coffee{
-		Name:       plan.Name.ValueString(),
-		Teaser:     plan.Teaser.ValueString(),
-		Origin:     plan.Origin.ValueString(),
-		Collection: plan.Collection.ValueString(),
-		Price:      float64(plan.Price.ValueInt64()),
-		Image:      plan.Image.ValueString(),
+		Name:        plan.Name.ValueString(),
+		Teaser:      plan.Teaser.ValueString(),
+		Origin:      plan.Origin.ValueString(),
+		Collection:  plan.Collection.ValueString(),
+		Description: plan.Description.ValueString(),
+		Color:       plan.Color.ValueString(),
+		Price:       price,
+		Currency:    plan.Currency.ValueString(),
+		Image:       plan.Image.ValueString(),
 	}
 	c, err := r.client.CreateCoffee(hashiCoffee)
 	if err != nil {
 		resp.Diagnostics.AddError(err.Error(), err.Error())
 		return
 	}
-	// Add coffee to state
 	plan.ID = types.StringValue(strconv.Itoa(c.ID))
-	resp.State.Set(ctx, plan)
 
-	for i := range plan.Ingredients {
-		hashiIngredient := hashicups.Ingredient{
-			Name:     plan.Ingredients[i].Name.ValueString(),
-			Quantity: int(plan.Ingredients[i].Quantity.ValueFloat64()),
-			Unit:     plan.Ingredients[i].Unit.ValueString(),
-		}
-		hi, err := r.client.CreateCoffeeIngredient(*c, hashiIngredient)
-		if err != nil {
-			resp.Diagnostics.AddError(err.Error(), err.Error())
-			return
-		}
-		plan.Ingredients[i].IngredientID = types.Int64Value(int64(hi.ID))
+	createNames := slices.Sorted(maps.Keys(plan.Ingredients))
+	resp.Diagnostics.Append(writeIngredients(ctx, plan.Ingredients, createNames, "Creating", func(ctx context.Context, ingredients []hashicups.Ingredient) ([]hashicups.IngredientResult, error) {
+		return r.client.CreateCoffeeIngredients(ctx, *c, ingredients)
+	})...)
+	if resp.Diagnostics.HasError() {
+		r.handleCreateFailure(ctx, plan, resp)
+		return
 	}
 	tflog.Info(ctx, fmt.Sprintf("c: %v", c))
 
@@ -192,6 +312,11 @@ func (r *coffeeResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 	c, err := r.client.GetCoffee(state.ID.ValueString())
+	if errors.Is(err, hashicups.ErrNotFound) {
+		// The coffee was deleted outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(err.Error(), err.Error())
 		return
@@ -203,20 +328,39 @@ func (r *coffeeResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	stateIngrediens := []ingredientModel{}
-	for _, ingredient := range ingredients {
+	// Overwrite every attribute with the refreshed coffee
+	state.Name = types.StringValue(c.Name)
+	state.Teaser = optionalStringValue(state.Teaser, c.Teaser)
+	state.Collection = optionalStringValue(state.Collection, c.Collection)
+	state.Origin = optionalStringValue(state.Origin, c.Origin)
+	state.Description = optionalStringValue(state.Description, c.Description)
+	state.Color = optionalStringValue(state.Color, c.Color)
+	state.Price = moneyValue(c.Price)
+	// The API only reports a currency when it stores one
+	if c.Currency != "" {
+		state.Currency = types.StringValue(c.Currency)
+	} else if state.Currency.IsNull() {
+		state.Currency = types.StringValue(defaultCurrency)
+	}
+	state.Image = optionalStringValue(state.Image, c.Image)
+	// The API does not store on_create_failure, so imported and upgraded
+	// state starts from the default
+	if state.OnCreateFailure.IsNull() {
+		state.OnCreateFailure = types.StringValue(onCreateFailureTaint)
+	}
 
-		stateIngrediens = append(stateIngrediens, ingredientModel{
+	// Keep ingredients null when there are none and none were configured
+	if len(ingredients) > 0 || state.Ingredients != nil {
+		state.Ingredients = map[string]ingredientModel{}
+	}
+	for _, ingredient := range ingredients {
+		state.Ingredients[ingredient.Name] = ingredientModel{
 			IngredientID: types.Int64Value(int64(ingredient.ID)),
-			Name:         types.StringValue(ingredient.Name),
-			Quantity:     types.Float64Value(float64(ingredient.Quantity)),
+			Quantity:     types.Float64Value(ingredient.Quantity),
 			Unit:         types.StringValue(ingredient.Unit),
-		})
-
+		}
 	}
 
-	state.Ingredients = stateIngrediens
-
 	state.ID = types.StringValue(strconv.Itoa(c.ID))
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -231,22 +375,31 @@ func (r *coffeeResource) Update(ctx context.Context, req resource.UpdateRequest,
 	var state coffeeResourceModel
 	var plan coffeeResourceModel
 	diags := req.Plan.Get(ctx, &plan)
-	// Get current ingredients and set to null if not present in plan
-	req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	// Get current ingredients to delete those no longer in plan
+	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	id, _ := strconv.Atoi(plan.ID.ValueString())
+	price, err := moneyFromNumber(plan.Price)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("price"), "Invalid Coffee Price", err.Error())
+		return
+	}
 	hashiCoffe := hashicups.Coffee{
-		ID:         id,
-		Name:       plan.Name.ValueString(),
-		Teaser:     plan.Teaser.ValueString(),
-		Price:      float64(plan.Price.ValueInt64()),
-		Image:      plan.Image.ValueString(),
-		Origin:     plan.Origin.ValueString(),
-		Collection: plan.Collection.ValueString(),
+		ID:          id,
+		Name:        plan.Name.ValueString(),
+		Teaser:      plan.Teaser.ValueString(),
+		Price:       price,
+		Currency:    plan.Currency.ValueString(),
+		Image:       plan.Image.ValueString(),
+		Origin:      plan.Origin.ValueString(),
+		Collection:  plan.Collection.ValueString(),
+		Description: plan.Description.ValueString(),
+		Color:       plan.Color.ValueString(),
 	}
 	c, err := r.client.UpdateCoffee(hashiCoffe)
 	if err != nil {
@@ -254,20 +407,37 @@ func (r *coffeeResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	collectedIngredients := r.CollectIngredientModels(ctx, state.Ingredients, plan.Ingredients)
-	for _, hashiIngredient := range collectedIngredients {
-		hi, err := r.client.CreateCoffeeIngredient(*c, hashiIngredient)
-		if err != nil {
-			resp.Diagnostics.AddError(err.Error(), err.Error())
-			return
-		}
-		tflog.Info(ctx, fmt.Sprintf("hi: %v\n", hi))
-
-		planIndex := slices.IndexFunc(plan.Ingredients, func(i ingredientModel) bool { return i.Name == types.StringValue(hi.Name) })
-		if planIndex > -1 {
-			plan.Ingredients[planIndex].IngredientID = types.Int64Value(int64(hi.ID))
+	changes := diffIngredients(state.Ingredients, plan.Ingredients)
+	deletes := changes.Delete
+	results, err := r.client.DeleteCoffeeIngredients(ctx, *c, deletes)
+	for i, result := range results {
+		if result.Err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ingredients").AtMapKey(deletes[i].Name),
+				"Error Deleting HashiCups Coffee Ingredient",
+				"Could not delete ingredient "+deletes[i].Name+", unexpected error: "+result.Err.Error(),
+			)
 		}
+	}
+	if err != nil && !resp.Diagnostics.HasError() {
+		resp.Diagnostics.AddError(
+			"Error Deleting HashiCups Coffee Ingredients",
+			"Could not delete the ingredients of coffee ID "+strconv.Itoa(c.ID)+", unexpected error: "+err.Error(),
+		)
+	}
+	// Keep the prior state, which still lists the ingredients that failed
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	resp.Diagnostics.Append(writeIngredients(ctx, plan.Ingredients, changes.Update, "Updating", func(ctx context.Context, ingredients []hashicups.Ingredient) ([]hashicups.IngredientResult, error) {
+		return r.client.UpdateCoffeeIngredients(ctx, *c, ingredients)
+	})...)
+	resp.Diagnostics.Append(writeIngredients(ctx, plan.Ingredients, changes.Create, "Creating", func(ctx context.Context, ingredients []hashicups.Ingredient) ([]hashicups.IngredientResult, error) {
+		return r.client.CreateCoffeeIngredients(ctx, *c, ingredients)
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("c: %v", c))
@@ -307,60 +477,166 @@ func (r *coffeeResource) Configure(_ context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*hashicups.Client)
+	data, ok := req.ProviderData.(*hashicupsResourceData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *hashicups.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *hashicupsResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
 }
 
 func (r *coffeeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
+	if _, err := strconv.Atoi(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected the numeric identifier of a coffee. Got: %q", req.ID),
+		)
+		return
+	}
+
+	// Retrieve import ID and save to id attribute. Read fills in the
+	// remaining attributes and the ingredients.
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
-func (r *coffeeResource) CollectIngredientModels(ctx context.Context, stateIngredients, planIngredients []ingredientModel) []hashicups.Ingredient {
-
-	collectedIngredients := []hashicups.Ingredient{}
-	for _, stateIngredient := range stateIngredients {
-		planIndex := slices.IndexFunc(planIngredients, func(i ingredientModel) bool { return i.Name == stateIngredient.Name })
-		var ingredientToUse *ingredientModel
-		if planIndex < 0 {
-			// Delete
-			ingredientToUse = &stateIngredient
-			ingredientToUse.Quantity = types.Float64Value(0)
-		} else {
-			// Update
-			ingredientToUse = &planIngredients[planIndex]
+// handleCreateFailure deals with a coffee that was created but could not get
+// all of its ingredients, following the on_create_failure setting.
+func (r *coffeeResource) handleCreateFailure(ctx context.Context, plan coffeeResourceModel, resp *resource.CreateResponse) {
+	if plan.OnCreateFailure.ValueString() == onCreateFailureRollback {
+		err := r.client.DeleteCoffee(plan.ID.ValueString())
+		if err == nil {
+			tflog.Info(ctx, "Rolled back partially created coffee", map[string]any{"id": plan.ID.ValueString()})
+			return
 		}
+		resp.Diagnostics.AddError(
+			"Error Rolling Back HashiCups Coffee",
+			"Could not delete partially created coffee "+plan.ID.ValueString()+", it is kept in state for replacement: "+err.Error(),
+		)
+	}
 
-		collectedIngredients = append(collectedIngredients, hashicups.Ingredient{
-			Name:     ingredientToUse.Name.ValueString(),
-			Quantity: int(ingredientToUse.Quantity.ValueFloat64()),
-			Unit:     ingredientToUse.Unit.ValueString(),
-		})
+	// Saving state alongside the error diagnostics makes Terraform taint the
+	// coffee. Ingredients that failed have no ID.
+	for name, ingredient := range plan.Ingredients {
+		if ingredient.IngredientID.IsUnknown() {
+			ingredient.IngredientID = types.Int64Null()
+			plan.Ingredients[name] = ingredient
+		}
 	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
 
-	// Create New Ingredients
-	for _, planIngredient := range planIngredients {
-		stateIndex := slices.IndexFunc(stateIngredients, func(i ingredientModel) bool { return i.Name == planIngredient.Name })
-		if stateIndex > 0 {
-			// We handled this one already
+// moneyFromNumber converts a Terraform number to an exact amount of money.
+func moneyFromNumber(n types.Number) (hashicups.Money, error) {
+	return hashicups.ParseMoney(n.ValueBigFloat().Text('f', -1))
+}
+
+// moneyValue converts an amount of money to a Terraform number, parsed the
+// same way Terraform parses numbers in configuration.
+func moneyValue(m hashicups.Money) types.Number {
+	f, _, _ := big.ParseFloat(m.String(), 10, 512, big.ToNearestEven)
+	return types.NumberValue(f)
+}
+
+// optionalStringValue maps an optional string attribute from the API. The
+// API does not distinguish unset from empty, so an empty value stays null
+// when it was null before.
+func optionalStringValue(prior types.String, value string) types.String {
+	if value == "" && prior.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// toHashicups converts the ingredient model to its API representation.
+func (m ingredientModel) toHashicups(name string) hashicups.Ingredient {
+	return hashicups.Ingredient{
+		ID:       int(m.IngredientID.ValueInt64()),
+		Name:     name,
+		Quantity: m.Quantity.ValueFloat64(),
+		Unit:     m.Unit.ValueString(),
+	}
+}
+
+// writeIngredients sends the plan ingredients with the given names to the
+// API in one parallel batch and records the returned IDs in the plan. Each
+// failed ingredient gets its own diagnostic pointing at its plan entry.
+func writeIngredients(ctx context.Context, planIngredients map[string]ingredientModel, names []string, action string, write func(context.Context, []hashicups.Ingredient) ([]hashicups.IngredientResult, error)) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(names) == 0 {
+		return diags
+	}
+
+	ingredients := make([]hashicups.Ingredient, len(names))
+	for i, name := range names {
+		ingredients[i] = planIngredients[name].toHashicups(name)
+	}
+
+	results, err := write(ctx, ingredients)
+	for i, result := range results {
+		name := names[i]
+		if result.Err != nil {
+			diags.AddAttributeError(
+				path.Root("ingredients").AtMapKey(name),
+				"Error "+action+" HashiCups Coffee Ingredient",
+				"Could not write ingredient "+name+", unexpected error: "+result.Err.Error(),
+			)
 			continue
 		}
-		collectedIngredients = append(collectedIngredients, hashicups.Ingredient{
-			Name:     planIngredient.Name.ValueString(),
-			Quantity: int(planIngredient.Quantity.ValueFloat64()),
-			Unit:     planIngredient.Unit.ValueString(),
-		})
+		ingredient := planIngredients[name]
+		ingredient.IngredientID = types.Int64Value(int64(result.Ingredient.ID))
+		planIngredients[name] = ingredient
+	}
+	if err != nil && !diags.HasError() {
+		diags.AddError(
+			"Error "+action+" HashiCups Coffee Ingredients",
+			"Could not write the coffee ingredients, unexpected error: "+err.Error(),
+		)
+	}
+
+	return diags
+}
+
+// ingredientChanges lists the ingredient API calls that move a coffee from
+// its state to its plan. Create and Update hold plan ingredient names, in
+// sorted order.
+type ingredientChanges struct {
+	Create []string
+	Update []string
+	Delete []hashicups.Ingredient
+}
+
+// diffIngredients compares state and plan ingredients by name. Plan
+// ingredients that already exist inherit the ingredient ID from state. An
+// ingredient whose amount is only expressed in another unit is not updated.
+func diffIngredients(stateIngredients, planIngredients map[string]ingredientModel) ingredientChanges {
+	changes := ingredientChanges{}
+
+	for _, name := range slices.Sorted(maps.Keys(stateIngredients)) {
+		if _, ok := planIngredients[name]; !ok {
+			changes.Delete = append(changes.Delete, stateIngredients[name].toHashicups(name))
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(planIngredients)) {
+		planIngredient := planIngredients[name]
+		stateIngredient, ok := stateIngredients[name]
+		if !ok {
+			changes.Create = append(changes.Create, name)
+			continue
+		}
+
+		planIngredient.IngredientID = stateIngredient.IngredientID
+		planIngredients[name] = planIngredient
+		if !sameQuantity(planIngredient.Quantity.ValueFloat64(), planIngredient.Unit.ValueString(), stateIngredient.Quantity.ValueFloat64(), stateIngredient.Unit.ValueString()) {
+			changes.Update = append(changes.Update, name)
+		}
 	}
 
-	return collectedIngredients
+	return changes
 }
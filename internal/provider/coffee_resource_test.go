@@ -1,11 +1,26 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"math/big"
 	"regexp"
+	"slices"
+	"strings"
 	"terraform-provider-hashicups/internal/provider/test/helper"
 	"testing"
 
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp-demoapp/hashicups-client-go/hashicupstest"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestCreateEmptyCoffeeAndAddIngredients(t *testing.T) {
@@ -20,14 +35,14 @@ func TestCreateEmptyCoffeeAndAddIngredients(t *testing.T) {
 					teaser = "exclusively for techdays 2025"
 					price = 150
 					image = "/terraform.png"
-					ingredients = []
+					ingredients = {}
 
 				}
 				`,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					helper.TestCheckNumberOfResources(1),
 					helper.TestCheckResourceExists("hashicups_coffee.test"),
-					resource.TestCheckNoResourceAttr("hashicups_coffee.test", "ingredients.0.name"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.%", "0"),
 				),
 			},
 			{
@@ -37,23 +52,18 @@ func TestCreateEmptyCoffeeAndAddIngredients(t *testing.T) {
 					teaser = "exclusively for techdays 2025"
 					price = 150
 					image = "/terraform.png"
-					ingredients = [{
-						name = "Espresso"
-						quantity = 50
-						unit = "ml"
-						},
-						{
-						name = "Steamed Milk"
-						quantity = 100
-						unit = "ml"
-					}]
+					ingredients = {
+						"Espresso" = { quantity = 50, unit = "ml" }
+						"Steamed Milk" = { quantity = 100, unit = "ml" }
+					}
 				}
 				`,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					helper.TestCheckNumberOfResources(1),
 					helper.TestCheckResourceExists("hashicups_coffee.test"),
-					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.0.name", "Espresso"),
-					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.1.name", "Steamed Milk"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.%", "2"),
+					resource.TestCheckResourceAttrSet("hashicups_coffee.test", "ingredients.Espresso.ingredient_id"),
+					resource.TestCheckResourceAttrSet("hashicups_coffee.test", "ingredients.Steamed Milk.ingredient_id"),
 				),
 			},
 		},
@@ -71,11 +81,9 @@ func TestWrongIngredients(t *testing.T) {
 					teaser = "exclusively for techdays 2025"
 					price = 150
 					image = "/terraform.png"
-					ingredients = [{
-						name = "Steamed Milk2"
-						quantity = 100
-						unit = "ml"
-					}]
+					ingredients = {
+						"Steamed Milk2" = { quantity = 100, unit = "ml" }
+					}
 
 				}`,
 				ExpectError: regexp.MustCompile("Error running apply"),
@@ -96,29 +104,21 @@ func TestAccCoffeeResource(t *testing.T) {
 					teaser = "exclusively for techdays 2025"
 					price = 150
 					image = "/terraform.png"
-					ingredients = [{
-						name = "Espresso"
-						quantity = 50
-						unit = "ml"
-						},
-						{
-						name = "Steamed Milk"
-						quantity = 100
-						unit = "ml"
-					}]
+					ingredients = {
+						"Espresso" = { quantity = 50, unit = "ml" }
+						"Steamed Milk" = { quantity = 100, unit = "ml" }
+					}
 
 				}
 				
 				resource "hashicups_coffee" "second_test" {
 					name = "random_mix"
 					teaser = "test only, not for consumption"
-					price = -1
+					price = 1
 					image = "/terraform.png"
-					ingredients = [{
-						name = "Hot Water"
-						quantity = 1
-						unit = "l"
-						}]
+					ingredients = {
+						"Hot Water" = { quantity = 1, unit = "l" }
+					}
 				}
 				`,
 				Check: resource.ComposeAggregateTestCheckFunc(
@@ -126,13 +126,18 @@ func TestAccCoffeeResource(t *testing.T) {
 					helper.TestCheckResourceExists("hashicups_coffee.test"),
 					helper.TestCheckResourceExists("hashicups_coffee.second_test"),
 					resource.TestCheckResourceAttr("hashicups_coffee.test", "name", "terraspiced latte"),
-					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.0.name", "Espresso"),
-					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.0.quantity", "50"),
-					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.0.unit", "ml"),
-					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.1.name", "Steamed Milk"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.Espresso.quantity", "50"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.Espresso.unit", "ml"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.Steamed Milk.quantity", "100"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "on_create_failure", "taint"),
 				),
 			},
 			// ImportState testing
+			{
+				ResourceName:      "hashicups_coffee.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 			// Update and Read testing
 			{
 				Config: providerConfig + `
@@ -141,21 +146,19 @@ func TestAccCoffeeResource(t *testing.T) {
 					teaser = "exclusively for techdays 2025"
 					price = 250
 					image = "/terraform.png"
-					ingredients = [{
-						name = "Espresso"
-						quantity = 10
-						unit = "dl"
-						}]
+					ingredients = {
+						"Espresso" = { quantity = 10, unit = "dl" }
+					}
 				}`,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					helper.TestCheckNumberOfResources(1),
 					helper.TestCheckResourceExists("hashicups_coffee.test"),
 					helper.TestCheckResourceNotExists("hashicups_coffee.second_test"),
 					resource.TestCheckResourceAttr("hashicups_coffee.test", "name", "terraspiced coffein booster"),
-					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.0.name", "Espresso"),
-					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.0.quantity", "10"),
-					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.0.unit", "dl"),
-					resource.TestCheckNoResourceAttr("hashicups_coffee.test", "ingredients.1.name"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.%", "1"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.Espresso.quantity", "10"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.Espresso.unit", "dl"),
+					resource.TestCheckNoResourceAttr("hashicups_coffee.test", "ingredients.Steamed Milk.quantity"),
 				),
 			},
 			{
@@ -165,24 +168,1041 @@ func TestAccCoffeeResource(t *testing.T) {
 					teaser = "exclusively for techdays 2025"
 					price = 250
 					image = "/terraform.png"
-					ingredients = [{
-						name = "Pumpkin Spice"
-						quantity = 1
-						unit = "ml"
-						}]
+					ingredients = {
+						"Pumpkin Spice" = { quantity = 1, unit = "ml" }
+					}
 				}`,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					helper.TestCheckNumberOfResources(1),
 					helper.TestCheckResourceExists("hashicups_coffee.test"),
 					helper.TestCheckResourceNotExists("hashicups_coffee.second_test"),
 					resource.TestCheckResourceAttr("hashicups_coffee.test", "name", "it's october I guess 🎃"),
-					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.0.name", "Pumpkin Spice"),
-					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.0.quantity", "1"),
-					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.0.unit", "ml"),
-					resource.TestCheckNoResourceAttr("hashicups_coffee.test", "ingredients.1.name"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.%", "1"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.Pumpkin Spice.quantity", "1"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.Pumpkin Spice.unit", "ml"),
+					resource.TestCheckNoResourceAttr("hashicups_coffee.test", "ingredients.Espresso.quantity"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestDiffIngredients(t *testing.T) {
+	ingredient := func(id int64, quantity float64, unit string) ingredientModel {
+		return ingredientModel{
+			IngredientID: types.Int64Value(id),
+			Quantity:     types.Float64Value(quantity),
+			Unit:         types.StringValue(unit),
+		}
+	}
+	planned := func(quantity float64, unit string) ingredientModel {
+		i := ingredient(0, quantity, unit)
+		i.IngredientID = types.Int64Unknown()
+		return i
+	}
+
+	tests := map[string]struct {
+		state map[string]ingredientModel
+		plan  map[string]ingredientModel
+
+		create []string
+		update []string
+		delete []string
+		// Plan ingredient IDs after diffing, nil for unknown.
+		ids map[string]any
+	}{
+		"no ingredients": {},
+		"all new": {
+			plan: map[string]ingredientModel{
+				"Steamed Milk": planned(200, "ml"),
+				"Espresso":     planned(40, "ml"),
+			},
+			create: []string{"Espresso", "Steamed Milk"},
+			ids:    map[string]any{"Espresso": nil, "Steamed Milk": nil},
+		},
+		"all removed": {
+			state: map[string]ingredientModel{
+				"Steamed Milk": ingredient(2, 200, "ml"),
+				"Espresso":     ingredient(1, 40, "ml"),
+			},
+			delete: []string{"Espresso", "Steamed Milk"},
+		},
+		"all removed to empty map": {
+			state: map[string]ingredientModel{
+				"Espresso": ingredient(1, 40, "ml"),
+			},
+			plan:   map[string]ingredientModel{},
+			delete: []string{"Espresso"},
+		},
+		"unchanged": {
+			state: map[string]ingredientModel{
+				"Espresso":     ingredient(1, 40, "ml"),
+				"Steamed Milk": ingredient(2, 200, "ml"),
+			},
+			plan: map[string]ingredientModel{
+				"Espresso":     planned(40, "ml"),
+				"Steamed Milk": planned(200, "ml"),
+			},
+			ids: map[string]any{"Espresso": int64(1), "Steamed Milk": int64(2)},
+		},
+		// The first ingredient in state used to be missed by a list
+		// index check.
+		"single unchanged": {
+			state: map[string]ingredientModel{
+				"Espresso": ingredient(1, 40, "ml"),
+			},
+			plan: map[string]ingredientModel{
+				"Espresso": planned(40, "ml"),
+			},
+			ids: map[string]any{"Espresso": int64(1)},
+		},
+		"quantity changed": {
+			state: map[string]ingredientModel{
+				"Espresso": ingredient(1, 40, "ml"),
+			},
+			plan: map[string]ingredientModel{
+				"Espresso": planned(60, "ml"),
+			},
+			update: []string{"Espresso"},
+			ids:    map[string]any{"Espresso": int64(1)},
+		},
+		"unit changed": {
+			state: map[string]ingredientModel{
+				"Espresso": ingredient(1, 40, "ml"),
+			},
+			plan: map[string]ingredientModel{
+				"Espresso": planned(40, "cl"),
+			},
+			update: []string{"Espresso"},
+			ids:    map[string]any{"Espresso": int64(1)},
+		},
+		"same amount in another unit": {
+			state: map[string]ingredientModel{
+				"Espresso": ingredient(1, 1000, "ml"),
+			},
+			plan: map[string]ingredientModel{
+				"Espresso": planned(10, "dl"),
+			},
+			ids: map[string]any{"Espresso": int64(1)},
+		},
+		"renamed": {
+			state: map[string]ingredientModel{
+				"Steamed Milk": ingredient(2, 200, "ml"),
+			},
+			plan: map[string]ingredientModel{
+				"Semi Skimmed Milk": planned(200, "ml"),
+			},
+			create: []string{"Semi Skimmed Milk"},
+			delete: []string{"Steamed Milk"},
+			ids:    map[string]any{"Semi Skimmed Milk": nil},
+		},
+		"mixed": {
+			state: map[string]ingredientModel{
+				"Espresso":      ingredient(1, 40, "ml"),
+				"Steamed Milk":  ingredient(2, 200, "ml"),
+				"Pumpkin Spice": ingredient(3, 5, "g"),
+				"Cocoa":         ingredient(4, 5, "g"),
+			},
+			plan: map[string]ingredientModel{
+				"Espresso":     planned(40, "ml"),
+				"Hot Water":    planned(100, "ml"),
+				"Steamed Milk": planned(300, "ml"),
+				"Cinnamon":     planned(1, "g"),
+			},
+			create: []string{"Cinnamon", "Hot Water"},
+			update: []string{"Steamed Milk"},
+			delete: []string{"Cocoa", "Pumpkin Spice"},
+			ids:    map[string]any{"Espresso": int64(1), "Hot Water": nil, "Steamed Milk": int64(2), "Cinnamon": nil},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			changes := diffIngredients(test.state, test.plan)
+
+			if !slices.Equal(changes.Create, test.create) {
+				t.Errorf("expected creates %v, got %v", test.create, changes.Create)
+			}
+			if !slices.Equal(changes.Update, test.update) {
+				t.Errorf("expected updates %v, got %v", test.update, changes.Update)
+			}
+			var deleted []string
+			for _, ingredient := range changes.Delete {
+				deleted = append(deleted, ingredient.Name)
+				if want := test.state[ingredient.Name].IngredientID.ValueInt64(); int64(ingredient.ID) != want {
+					t.Errorf("expected %s to be deleted by ID %d, got %d", ingredient.Name, want, ingredient.ID)
+				}
+			}
+			if !slices.Equal(deleted, test.delete) {
+				t.Errorf("expected deletes %v, got %v", test.delete, deleted)
+			}
+
+			if len(test.plan) != len(test.ids) {
+				t.Fatalf("expected %d plan ingredients, got %d", len(test.ids), len(test.plan))
+			}
+			for name, id := range test.ids {
+				got := test.plan[name].IngredientID
+				switch id := id.(type) {
+				case nil:
+					if !got.IsUnknown() {
+						t.Errorf("expected new ingredient %s to keep an unknown ID, got %v", name, got)
+					}
+				case int64:
+					if got.ValueInt64() != id {
+						t.Errorf("expected ingredient %s to keep ID %d, got %v", name, id, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCoffeeResourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewCoffeeResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	valid := func() coffeeResourceModel {
+		return coffeeResourceModel{
+			ID:              types.StringUnknown(),
+			Name:            types.StringValue("validated latte"),
+			Teaser:          types.StringValue("checked before ordering"),
+			Collection:      types.StringNull(),
+			Origin:          types.StringNull(),
+			Color:           types.StringNull(),
+			Description:     types.StringNull(),
+			Price:           moneyValue(hashicups.MoneyFromCents(350)),
+			Currency:        types.StringNull(),
+			Image:           types.StringNull(),
+			OnCreateFailure: types.StringNull(),
+			Ingredients: map[string]ingredientModel{
+				"Espresso": {IngredientID: types.Int64Null(), Quantity: types.Float64Value(40), Unit: types.StringValue("ml")},
+			},
+		}
+	}
+	ingredient := ingredientModel{IngredientID: types.Int64Null(), Quantity: types.Float64Value(1), Unit: types.StringValue("g")}
+
+	tests := map[string]struct {
+		edit  func(*coffeeResourceModel)
+		paths []path.Path
+	}{
+		"valid": {
+			edit: func(*coffeeResourceModel) {},
+		},
+		"free": {
+			edit: func(m *coffeeResourceModel) { m.Price = moneyValue(hashicups.MoneyFromCents(0)) },
+		},
+		"unknown values": {
+			edit: func(m *coffeeResourceModel) {
+				m.Price = types.NumberUnknown()
+				m.Teaser = types.StringUnknown()
+			},
+		},
+		"negative price": {
+			edit:  func(m *coffeeResourceModel) { m.Price = moneyValue(hashicups.MoneyFromCents(-100)) },
+			paths: []path.Path{path.Root("price")},
+		},
+		"long teaser": {
+			edit:  func(m *coffeeResourceModel) { m.Teaser = types.StringValue(strings.Repeat("☕", maxTeaserLength+1)) },
+			paths: []path.Path{path.Root("teaser")},
+		},
+		"teaser at the limit": {
+			edit: func(m *coffeeResourceModel) { m.Teaser = types.StringValue(strings.Repeat("☕", maxTeaserLength)) },
+		},
+		"empty ingredient name": {
+			edit:  func(m *coffeeResourceModel) { m.Ingredients[" "] = ingredient },
+			paths: []path.Path{path.Root("ingredients").AtMapKey(" ")},
+		},
+		"duplicate ingredient names": {
+			edit: func(m *coffeeResourceModel) {
+				m.Ingredients["espresso"] = ingredient
+				m.Ingredients["Espresso "] = ingredient
+			},
+			paths: []path.Path{
+				path.Root("ingredients").AtMapKey("Espresso "),
+				path.Root("ingredients").AtMapKey("espresso"),
+			},
+		},
+		"several problems": {
+			edit: func(m *coffeeResourceModel) {
+				m.Price = moneyValue(hashicups.MoneyFromCents(-1))
+				m.Ingredients[""] = ingredient
+			},
+			paths: []path.Path{path.Root("price"), path.Root("ingredients").AtMapKey("")},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			model := valid()
+			test.edit(&model)
+
+			req := fwresource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: coffeeModelValue(t, model)},
+			}
+			resp := &fwresource.ValidateConfigResponse{}
+			NewCoffeeResource().(fwresource.ResourceWithValidateConfig).ValidateConfig(ctx, req, resp)
+
+			var paths []path.Path
+			for _, d := range resp.Diagnostics.Errors() {
+				withPath, ok := d.(diag.DiagnosticWithPath)
+				if !ok {
+					t.Fatalf("expected diagnostic with attribute path, got %v", d)
+				}
+				paths = append(paths, withPath.Path())
+			}
+			if !slices.EqualFunc(paths, test.paths, path.Path.Equal) {
+				t.Errorf("expected errors at %v, got %v", test.paths, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestCoffeeResourceUpdateInvalidState(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	r := &coffeeResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: coffeeModelValue(t, coffeeResourceModel{
+		ID:              types.StringValue("1"),
+		Name:            types.StringValue("HCP Aeropress"),
+		Teaser:          types.StringNull(),
+		Collection:      types.StringNull(),
+		Origin:          types.StringNull(),
+		Color:           types.StringNull(),
+		Description:     types.StringNull(),
+		Price:           moneyValue(hashicups.MoneyFromUnits(200)),
+		Currency:        types.StringValue(defaultCurrency),
+		Image:           types.StringNull(),
+		OnCreateFailure: types.StringValue(onCreateFailureTaint),
+	})}
+	// State that does not match the schema fails to decode
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(tftypes.String, "corrupt")}
+
+	resp := &fwresource.UpdateResponse{State: state}
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an error for state that does not decode")
+	}
+	if coffee, _ := server.Coffee(1); len(coffee.Ingredient) == 0 {
+		t.Error("expected the ingredients of the coffee to be kept")
+	}
+}
+
+func TestAccCoffeeResourceValidation(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+
+	config := func(attributes string) string {
+		return testAccProviderConfig(server) + `
+		resource "hashicups_coffee" "test" {
+			name = "invalid espresso"
+			` + attributes + `
+		}
+		`
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(`price = -1`),
+				ExpectError: regexp.MustCompile("Invalid Coffee Price"),
+			},
+			{
+				Config:      config(`price = 1` + "\n" + `teaser = "` + strings.Repeat("x", maxTeaserLength+1) + `"`),
+				ExpectError: regexp.MustCompile("Invalid Coffee Teaser"),
+			},
+			{
+				Config: config(`price = 1
+				ingredients = {
+					"Espresso" = { quantity = 40, unit = "ml" }
+					"espresso" = { quantity = 20, unit = "ml" }
+				}`),
+				ExpectError: regexp.MustCompile("Duplicate Ingredient"),
+			},
+			{
+				Config: config(`price = 1
+				ingredients = {
+					"" = { quantity = 40, unit = "ml" }
+				}`),
+				ExpectError: regexp.MustCompile("Invalid Ingredient Name"),
+			},
+			{
+				Config: config(`price = 1
+				ingredients = {
+					"Espresso" = { quantity = 40, unit = "bucket" }
+				}`),
+				ExpectError: regexp.MustCompile("must be one of"),
+			},
+			// Nothing reached the API
+			{
+				Config:      config(`price = -1`),
+				ExpectError: regexp.MustCompile("Invalid Coffee Price"),
+				Check:       testAccCheckNoCoffeeNamed(server, "invalid espresso"),
+			},
+		},
+		CheckDestroy: testAccCheckNoCoffeeNamed(server, "invalid espresso"),
+	})
+}
+
+func TestMoneyNumberConversion(t *testing.T) {
+	for _, input := range []string{"3.5", "3.51", "0.1", "150", "1234567.89"} {
+		f, _, err := big.ParseFloat(input, 10, 512, big.ToNearestEven)
+		if err != nil {
+			t.Fatal(err)
+		}
+		number := types.NumberValue(f)
+
+		m, err := moneyFromNumber(number)
+		if err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+		if !moneyValue(m).Equal(number) {
+			t.Errorf("%s: expected round trip through %s to keep the value, got %s", input, m, moneyValue(m))
+		}
+	}
+
+	if _, err := moneyFromNumber(types.NumberValue(big.NewFloat(3.505))); err == nil {
+		t.Error("expected fractions of a cent to be rejected")
+	}
+}
+
+func TestWriteIngredientsFailures(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+	server.FailIngredient("Broken Syrup")
+	server.FailIngredient("Burnt Caramel")
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.MaxConcurrency = 2
+
+	coffee, err := client.CreateCoffee(hashicups.Coffee{Name: "failing flat white", Price: hashicups.MoneyFromUnits(200)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ingredients := map[string]ingredientModel{}
+	names := []string{"Broken Syrup", "Burnt Caramel", "Espresso", "Steamed Milk"}
+	for _, name := range names {
+		ingredients[name] = ingredientModel{
+			IngredientID: types.Int64Unknown(),
+			Quantity:     types.Float64Value(10),
+			Unit:         types.StringValue("ml"),
+		}
+	}
+
+	diags := writeIngredients(context.Background(), ingredients, names, "Creating", func(ctx context.Context, ingredients []hashicups.Ingredient) ([]hashicups.IngredientResult, error) {
+		return client.CreateCoffeeIngredients(ctx, *coffee, ingredients)
+	})
+
+	want := []path.Path{
+		path.Root("ingredients").AtMapKey("Broken Syrup"),
+		path.Root("ingredients").AtMapKey("Burnt Caramel"),
+	}
+	if got := diagnosticPaths(t, diags); !slices.EqualFunc(got, want, path.Path.Equal) {
+		t.Errorf("expected one error per failed ingredient at %v, got %v", want, diags)
+	}
+	for _, name := range []string{"Espresso", "Steamed Milk"} {
+		if ingredients[name].IngredientID.IsUnknown() {
+			t.Errorf("expected ingredient %s to get an ID", name)
+		}
+	}
+}
+
+func TestAccCoffeeResourceParallelIngredients(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+	server.FailIngredient("Broken Syrup")
+	server.FailIngredient("Burnt Caramel")
+
+	config := func(ingredients string) string {
+		return fmt.Sprintf(`
+		provider "hashicups" {
+			username        = %q
+			password        = %q
+			host            = %q
+			max_concurrency = 2
+		}
+
+		resource "hashicups_coffee" "test" {
+			name = "terraspiced flat white"
+			price = 200
+			ingredients = {%s}
+		}
+		`, hashicupstest.Username, hashicupstest.Password, server.URL, ingredients)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+					"Espresso" = { quantity = 40, unit = "ml" }
+					"Semi Skimmed Milk" = { quantity = 100, unit = "ml" }
+					"Hot Water" = { quantity = 20, unit = "ml" }
+					"Pumpkin Spice" = { quantity = 5, unit = "g" }
+					"Steamed Milk" = { quantity = 50, unit = "ml" }
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.%", "5"),
+					resource.TestCheckResourceAttrSet("hashicups_coffee.test", "ingredients.Espresso.ingredient_id"),
+					resource.TestCheckResourceAttrSet("hashicups_coffee.test", "ingredients.Steamed Milk.ingredient_id"),
+				),
+			},
+			{
+				Config: config(`
+					"Espresso" = { quantity = 60, unit = "ml" }
+					"Semi Skimmed Milk" = { quantity = 100, unit = "ml" }
+					"Steamed Milk" = { quantity = 80, unit = "ml" }
+				`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.%", "3"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.Espresso.quantity", "60"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.Steamed Milk.quantity", "80"),
+				),
+			},
+			// Every failed ingredient of the parallel batch gets its own error
+			{
+				Config: config(`
+					"Espresso" = { quantity = 60, unit = "ml" }
+					"Steamed Milk" = { quantity = 80, unit = "ml" }
+					"Broken Syrup" = { quantity = 10, unit = "ml" }
+					"Burnt Caramel" = { quantity = 5, unit = "g" }
+				`),
+				ExpectError: regexp.MustCompile(`(?s)Could not write ingredient Broken\s+Syrup.*Could not write ingredient Burnt\s+Caramel`),
+			},
+		},
+	})
+}
+
+func TestAccCoffeeResourceIngredientOrder(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+
+	config := func(ingredients string) string {
+		return testAccProviderConfig(server) + `
+		resource "hashicups_coffee" "test" {
+			name = "reordered cappuccino"
+			price = 300
+			ingredients = {` + ingredients + `}
+		}
+		`
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+					"Espresso" = { quantity = 40, unit = "ml" }
+					"Steamed Milk" = { quantity = 100, unit = "ml" }
+					"Cocoa" = { quantity = 2, unit = "g" }
+				`),
+			},
+			// Reordering in configuration changes nothing
+			{
+				Config: config(`
+					"Cocoa" = { quantity = 2, unit = "g" }
+					"Steamed Milk" = { quantity = 100, unit = "ml" }
+					"Espresso" = { quantity = 40, unit = "ml" }
+				`),
+				PlanOnly: true,
+			},
+			// Neither does the API returning another order
+			{
+				PreConfig: testAccEditCoffee(t, server, "reordered cappuccino", func(coffee *hashicups.Coffee) {
+					slices.Reverse(coffee.Ingredient)
+				}),
+				Config: config(`
+					"Espresso" = { quantity = 40, unit = "ml" }
+					"Steamed Milk" = { quantity = 100, unit = "ml" }
+					"Cocoa" = { quantity = 2, unit = "g" }
+				`),
+				PlanOnly: true,
+			},
+		},
+		CheckDestroy: testAccCheckNoCoffeeNamed(server, "reordered cappuccino"),
+	})
+}
+
+func TestAccCoffeeResourceIngredientUnits(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+
+	config := func(quantity, unit string) string {
+		return testAccProviderConfig(server) + fmt.Sprintf(`
+		resource "hashicups_coffee" "test" {
+			name = "metric americano"
+			price = 250
+			ingredients = {
+				"Hot Water" = { quantity = %s, unit = %q }
+			}
+		}
+		`, quantity, unit)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("1", "bucket"),
+				ExpectError: regexp.MustCompile(`must be one of`),
+			},
+			{
+				Config: config("10", "dl"),
+			},
+			// The API answering in its canonical unit is not a change
+			{
+				PreConfig: testAccEditCoffee(t, server, "metric americano", func(coffee *hashicups.Coffee) {
+					coffee.Ingredient[0].Quantity = 1000
+					coffee.Ingredient[0].Unit = "ml"
+				}),
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.Hot Water.quantity", "10"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.Hot Water.unit", "dl"),
+				),
+			},
+			{
+				Config:   config("10", "dl"),
+				PlanOnly: true,
+			},
+			// Neither is writing the same amount in another unit
+			{
+				Config:   config("1000", "ml"),
+				PlanOnly: true,
+			},
+			// A different amount is
+			{
+				PreConfig: testAccEditCoffee(t, server, "metric americano", func(coffee *hashicups.Coffee) {
+					coffee.Ingredient[0].Quantity = 500
+				}),
+				Config:             config("10", "dl"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+		CheckDestroy: testAccCheckNoCoffeeNamed(server, "metric americano"),
+	})
+}
+
+func TestAccCoffeeResourceFractionalQuantities(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+
+	config := testAccProviderConfig(server) + `
+	resource "hashicups_coffee" "test" {
+		name = "fractional mocha"
+		price = 4.25
+		ingredients = {
+			"Espresso" = { quantity = 1.5, unit = "shot" }
+			"Cocoa" = { quantity = 7.5, unit = "g" }
+		}
+	}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.Espresso.quantity", "1.5"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.Cocoa.quantity", "7.5"),
+					testAccCheckCoffee(server, "fractional mocha", func(coffee hashicups.Coffee) error {
+						quantities := map[string]float64{}
+						for _, ingredient := range coffee.Ingredient {
+							quantities[ingredient.Name] = ingredient.Quantity
+						}
+						if quantities["Espresso"] != 1.5 || quantities["Cocoa"] != 7.5 {
+							return fmt.Errorf("expected quantities to be stored unchanged, got %v", quantities)
+						}
+						return nil
+					}),
+				),
+			},
+			// Refreshing fractional quantities leaves nothing to change
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				PreConfig: testAccEditCoffee(t, server, "fractional mocha", func(coffee *hashicups.Coffee) {
+					for i := range coffee.Ingredient {
+						if coffee.Ingredient[i].Name == "Cocoa" {
+							coffee.Ingredient[i].Quantity = 8
+						}
+					}
+				}),
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+		CheckDestroy: testAccCheckNoCoffeeNamed(server, "fractional mocha"),
+	})
+}
+
+func TestAccCoffeeResourceCreateFailureRollback(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+	server.FailIngredient("Broken Syrup")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+				resource "hashicups_coffee" "test" {
+					name = "half built latte"
+					price = 150
+					on_create_failure = "rollback"
+					ingredients = {
+						"Espresso" = { quantity = 40, unit = "ml" }
+						"Broken Syrup" = { quantity = 10, unit = "ml" }
+					}
+				}
+				`,
+				ExpectError: regexp.MustCompile("Error Creating HashiCups Coffee Ingredient"),
+			},
+		},
+		CheckDestroy: testAccCheckNoCoffeeNamed(server, "half built latte"),
+	})
+}
+
+func TestAccCoffeeResourceCreateFailureTaint(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+	server.FailIngredient("Broken Syrup")
+
+	config := testAccProviderConfig(server) + `
+	resource "hashicups_coffee" "test" {
+		name = "half built latte"
+		price = 150
+		on_create_failure = "taint"
+		ingredients = {
+			"Espresso" = { quantity = 40, unit = "ml" }
+			"Broken Syrup" = { quantity = 10, unit = "ml" }
+		}
+	}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile("Error Creating HashiCups Coffee Ingredient"),
+			},
+			// The half built coffee is tainted and gets replaced once the
+			// API recovers.
+			{
+				PreConfig: server.ClearFaults,
+				Config:    config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("hashicups_coffee.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "ingredients.%", "2"),
+					resource.TestCheckResourceAttrSet("hashicups_coffee.test", "ingredients.Broken Syrup.ingredient_id"),
+					testAccCheckCoffeesNamed(server, "half built latte", 1),
+				),
+			},
+		},
+		CheckDestroy: testAccCheckNoCoffeeNamed(server, "half built latte"),
+	})
+}
+
+// testAccCheckCoffeesNamed verifies how many coffees with the given name the
+// server holds.
+func testAccCheckCoffeesNamed(server *hashicupstest.Server, name string, expected int) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		count := 0
+		for _, coffee := range server.Coffees() {
+			if coffee.Name == name {
+				count++
+			}
+		}
+		if count != expected {
+			return fmt.Errorf("expected %d coffees named %q, got %d", expected, name, count)
+		}
+		return nil
+	}
+}
+
+func testAccCheckNoCoffeeNamed(server *hashicupstest.Server, name string) resource.TestCheckFunc {
+	return testAccCheckCoffeesNamed(server, name, 0)
+}
+
+func TestAccCoffeeResourceDrift(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+
+	config := testAccProviderConfig(server) + `
+	resource "hashicups_coffee" "test" {
+		name = "drifting mocha"
+		teaser = "changes when nobody looks"
+		collection = "Drift"
+		price = 150
+		ingredients = {
+			"Espresso" = { quantity = 40, unit = "ml" }
+		}
+	}
+	`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "teaser", "changes when nobody looks"),
+					resource.TestCheckNoResourceAttr("hashicups_coffee.test", "origin"),
+					resource.TestCheckNoResourceAttr("hashicups_coffee.test", "image"),
+				),
+			},
+			// Edits made outside of Terraform show up after refresh
+			{
+				PreConfig: testAccEditCoffee(t, server, "drifting mocha", func(coffee *hashicups.Coffee) {
+					coffee.Name = "drifted mocha"
+					coffee.Teaser = "edited in the admin UI"
+					coffee.Collection = ""
+					coffee.Origin = "Winter 2025"
+					coffee.Description = "Now with a description"
+					coffee.Color = "#0F3B5C"
+					coffee.Price = hashicups.MoneyFromUnits(175)
+					coffee.Image = "/drift.png"
+				}),
+				RefreshState:       true,
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "name", "drifted mocha"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "teaser", "edited in the admin UI"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "collection", ""),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "origin", "Winter 2025"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "description", "Now with a description"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "color", "#0F3B5C"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "price", "175"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "image", "/drift.png"),
+				),
+			},
+			// Applying the configuration again reverts the edits
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "name", "drifting mocha"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "price", "150"),
+					testAccCheckCoffeesNamed(server, "drifting mocha", 1),
+				),
+			},
+			// Any single out-of-band edit produces a plan
+			{
+				PreConfig: testAccEditCoffee(t, server, "drifting mocha", func(coffee *hashicups.Coffee) {
+					coffee.Price = hashicups.MoneyFromUnits(999)
+				}),
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+		CheckDestroy: testAccCheckNoCoffeeNamed(server, "drifting mocha"),
+	})
+}
+
+func TestAccCoffeeResourceColorDescription(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+
+	config := func(color, description string) string {
+		return testAccProviderConfig(server) + fmt.Sprintf(`
+		resource "hashicups_coffee" "test" {
+			name = "colorful flat white"
+			price = 250
+			color = %q
+			description = %q
+		}
+		`, color, description)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("blue", "Not a hex code"),
+				ExpectError: regexp.MustCompile("must be a hex color code"),
+			},
+			{
+				Config: config("#444", "Dark and smooth"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "color", "#444"),
+					testAccCheckCoffee(server, "colorful flat white", func(coffee hashicups.Coffee) error {
+						if coffee.Color != "#444" || coffee.Description != "Dark and smooth" {
+							return fmt.Errorf("expected color and description to be sent on create, got %q and %q", coffee.Color, coffee.Description)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config: config("#0F3B5C", "Lighter than before"),
+				Check: testAccCheckCoffee(server, "colorful flat white", func(coffee hashicups.Coffee) error {
+					if coffee.Color != "#0F3B5C" || coffee.Description != "Lighter than before" {
+						return fmt.Errorf("expected color and description to be sent on update, got %q and %q", coffee.Color, coffee.Description)
+					}
+					return nil
+				}),
+			},
+		},
+		CheckDestroy: testAccCheckNoCoffeeNamed(server, "colorful flat white"),
+	})
+}
+
+func TestAccCoffeeResourceDecimalPrice(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+
+	config := func(price, currency string) string {
+		return testAccProviderConfig(server) + fmt.Sprintf(`
+		resource "hashicups_coffee" "test" {
+			name = "decimal doppio"
+			price = %s
+			currency = %q
+		}
+		`, price, currency)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("3.505", "EUR"),
+				ExpectError: regexp.MustCompile("at most two decimal places"),
+			},
+			{
+				Config:      config("3.50", "euro"),
+				ExpectError: regexp.MustCompile("must be a three letter currency code"),
+			},
+			{
+				Config: config("3.50", "EUR"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "price", "3.5"),
+					resource.TestCheckResourceAttr("hashicups_coffee.test", "currency", "EUR"),
+					testAccCheckCoffee(server, "decimal doppio", func(coffee hashicups.Coffee) error {
+						if coffee.Price != hashicups.MoneyFromCents(350) || coffee.Currency != "EUR" {
+							return fmt.Errorf("expected 3.50 EUR, got %s %s", coffee.Price, coffee.Currency)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config: config("0.1", "EUR"),
+				Check: testAccCheckCoffee(server, "decimal doppio", func(coffee hashicups.Coffee) error {
+					if coffee.Price != hashicups.MoneyFromCents(10) {
+						return fmt.Errorf("expected 0.10, got %s", coffee.Price)
+					}
+					return nil
+				}),
+			},
+			{
+				ResourceName:      "hashicups_coffee.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: testAccCheckNoCoffeeNamed(server, "decimal doppio"),
+	})
+}
+
+// testAccCheckCoffee runs check against the coffee with the given name on the
+// test server.
+func testAccCheckCoffee(server *hashicupstest.Server, name string, check func(hashicups.Coffee) error) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		for _, coffee := range server.Coffees() {
+			if coffee.Name == name {
+				return check(coffee)
+			}
+		}
+		return fmt.Errorf("coffee %q not found on test server", name)
+	}
+}
+
+// testAccEditCoffee returns a PreConfig function that changes a coffee
+// directly on the server, as if edited in the HashiCups admin UI.
+func testAccEditCoffee(t *testing.T, server *hashicupstest.Server, name string, edit func(*hashicups.Coffee)) func() {
+	return func() {
+		for _, coffee := range server.Coffees() {
+			if coffee.Name == name {
+				edit(&coffee)
+				server.SetCoffee(coffee)
+				return
+			}
+		}
+		t.Fatalf("coffee %q not found on test server", name)
+	}
+}
+
+func TestAccCoffeeResourceImport(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+				resource "hashicups_coffee" "test" {
+					name = "imported cortado"
+					teaser = "arrives fully formed"
+					collection = "Imports"
+					origin = "Autumn 2025"
+					description = "Every attribute survives the import."
+					color = "#a3c"
+					price = 300
+					image = "/import.png"
+					on_create_failure = "rollback"
+					ingredients = {
+						"Espresso" = { quantity = 40, unit = "ml" }
+						"Steamed Milk" = { quantity = 60, unit = "ml" }
+					}
+				}
+				`,
+			},
+			{
+				ResourceName:      "hashicups_coffee.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// on_create_failure only affects Terraform and is not stored
+				// in the HashiCups API.
+				ImportStateVerifyIgnore: []string{"on_create_failure"},
+			},
+			{
+				ResourceName:  "hashicups_coffee.test",
+				ImportState:   true,
+				ImportStateId: "not-a-number",
+				ExpectError:   regexp.MustCompile("Unexpected Import Identifier"),
+			},
+			{
+				ResourceName:  "hashicups_coffee.test",
+				ImportState:   true,
+				ImportStateId: "9999",
+				ExpectError:   regexp.MustCompile("Cannot import non-existent remote object"),
+			},
+		},
+	})
+}
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// UpgradeState migrates state written by earlier versions of the schema.
+// Every upgrader converts straight to the current schema.
+func (r *coffeeResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   coffeeSchemaV0(),
+			StateUpgrader: upgradeCoffeeStateV0,
+		},
+		1: {
+			PriorSchema:   coffeeSchemaV1(),
+			StateUpgrader: upgradeCoffeeStateV1,
+		},
+	}
+}
+
+// coffeeResourceModelV0 maps the version 0 schema data, which stored the
+// price as a whole number.
+type coffeeResourceModelV0 struct {
+	ID              types.String        `tfsdk:"id"`
+	Name            types.String        `tfsdk:"name"`
+	Teaser          types.String        `tfsdk:"teaser"`
+	Collection      types.String        `tfsdk:"collection"`
+	Origin          types.String        `tfsdk:"origin"`
+	Color           types.String        `tfsdk:"color"`
+	Description     types.String        `tfsdk:"description"`
+	Price           types.Int64         `tfsdk:"price"`
+	Image           types.String        `tfsdk:"image"`
+	Ingredients     []ingredientModelV1 `tfsdk:"ingredients"`
+	OnCreateFailure types.String        `tfsdk:"on_create_failure"`
+}
+
+// coffeeResourceModelV1 maps the version 1 schema data, which stored the
+// ingredients as a list.
+type coffeeResourceModelV1 struct {
+	ID              types.String        `tfsdk:"id"`
+	Name            types.String        `tfsdk:"name"`
+	Teaser          types.String        `tfsdk:"teaser"`
+	Collection      types.String        `tfsdk:"collection"`
+	Origin          types.String        `tfsdk:"origin"`
+	Color           types.String        `tfsdk:"color"`
+	Description     types.String        `tfsdk:"description"`
+	Price           types.Number        `tfsdk:"price"`
+	Currency        types.String        `tfsdk:"currency"`
+	Image           types.String        `tfsdk:"image"`
+	Ingredients     []ingredientModelV1 `tfsdk:"ingredients"`
+	OnCreateFailure types.String        `tfsdk:"on_create_failure"`
+}
+
+// ingredientModelV1 maps an ingredient list entry of schema versions 0 and 1.
+type ingredientModelV1 struct {
+	IngredientID types.Int64   `tfsdk:"ingredient_id"`
+	Name         types.String  `tfsdk:"name"`
+	Quantity     types.Float64 `tfsdk:"quantity"`
+	Unit         types.String  `tfsdk:"unit"`
+}
+
+// coffeeSchemaV0 returns the version 0 schema, used to decode old state.
+// Attributes added later within version 0, such as on_create_failure, decode
+// as null from state written before they existed.
+func coffeeSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                schema.StringAttribute{Computed: true},
+			"name":              schema.StringAttribute{Required: true},
+			"teaser":            schema.StringAttribute{Optional: true},
+			"collection":        schema.StringAttribute{Optional: true},
+			"origin":            schema.StringAttribute{Optional: true},
+			"color":             schema.StringAttribute{Optional: true},
+			"description":       schema.StringAttribute{Optional: true},
+			"price":             schema.Int64Attribute{Required: true},
+			"image":             schema.StringAttribute{Optional: true},
+			"on_create_failure": schema.StringAttribute{Optional: true},
+			"ingredients":       ingredientsAttributeV1(),
+		},
+	}
+}
+
+// coffeeSchemaV1 returns the version 1 schema, used to decode old state.
+func coffeeSchemaV1() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                schema.StringAttribute{Computed: true},
+			"name":              schema.StringAttribute{Required: true},
+			"teaser":            schema.StringAttribute{Optional: true},
+			"collection":        schema.StringAttribute{Optional: true},
+			"origin":            schema.StringAttribute{Optional: true},
+			"color":             schema.StringAttribute{Optional: true},
+			"description":       schema.StringAttribute{Optional: true},
+			"price":             schema.NumberAttribute{Required: true},
+			"currency":          schema.StringAttribute{Optional: true, Computed: true},
+			"image":             schema.StringAttribute{Optional: true},
+			"on_create_failure": schema.StringAttribute{Optional: true},
+			"ingredients":       ingredientsAttributeV1(),
+		},
+	}
+}
+
+// ingredientsAttributeV1 returns the ingredient list of schema versions 0
+// and 1.
+func ingredientsAttributeV1() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Optional: true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"ingredient_id": schema.Int64Attribute{Computed: true},
+				"name":          schema.StringAttribute{Optional: true},
+				"quantity":      schema.Float64Attribute{Required: true},
+				"unit":          schema.StringAttribute{Required: true},
+			},
+		},
+	}
+}
+
+// upgradeCoffeeStateV0 converts the whole number price of version 0 state to
+// an exact number in the default currency.
+func upgradeCoffeeStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior coffeeResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Whole number prices were always in USD
+	price := types.NumberNull()
+	if !prior.Price.IsNull() {
+		price = moneyValue(hashicups.MoneyFromUnits(prior.Price.ValueInt64()))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, coffeeResourceModel{
+		ID:              prior.ID,
+		Name:            prior.Name,
+		Teaser:          prior.Teaser,
+		Collection:      prior.Collection,
+		Origin:          prior.Origin,
+		Color:           prior.Color,
+		Description:     prior.Description,
+		Price:           price,
+		Currency:        types.StringValue(defaultCurrency),
+		Image:           prior.Image,
+		Ingredients:     ingredientsByName(prior.Ingredients),
+		OnCreateFailure: prior.OnCreateFailure,
+	})...)
+}
+
+// upgradeCoffeeStateV1 converts the ingredient list of version 1 state to a
+// map keyed by ingredient name.
+func upgradeCoffeeStateV1(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior coffeeResourceModelV1
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, coffeeResourceModel{
+		ID:              prior.ID,
+		Name:            prior.Name,
+		Teaser:          prior.Teaser,
+		Collection:      prior.Collection,
+		Origin:          prior.Origin,
+		Color:           prior.Color,
+		Description:     prior.Description,
+		Price:           prior.Price,
+		Currency:        prior.Currency,
+		Image:           prior.Image,
+		Ingredients:     ingredientsByName(prior.Ingredients),
+		OnCreateFailure: prior.OnCreateFailure,
+	})...)
+}
+
+// ingredientsByName converts an ingredient list to a map keyed by name. The
+// API only keeps one ingredient per name, so when the list has duplicates the
+// last entry wins, as it did on the server.
+func ingredientsByName(ingredients []ingredientModelV1) map[string]ingredientModel {
+	if ingredients == nil {
+		return nil
+	}
+
+	byName := make(map[string]ingredientModel, len(ingredients))
+	for _, ingredient := range ingredients {
+		byName[ingredient.Name.ValueString()] = ingredientModel{
+			IngredientID: ingredient.IngredientID,
+			Quantity:     ingredient.Quantity,
+			Unit:         ingredient.Unit,
+		}
+	}
+	return byName
+}
@@ -0,0 +1,293 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// upgradeCoffeeState sends raw state JSON of the given schema version through
+// the provider server, the way Terraform does, and decodes the upgraded state.
+func upgradeCoffeeState(t *testing.T, version int64, state string) coffeeResourceModel {
+	t.Helper()
+	var model coffeeResourceModel
+	upgradeResourceState(t, NewCoffeeResource(), "hashicups_coffee", version, state, &model)
+	return model
+}
+
+// assertCoffeeModel compares models by their Terraform values, so numbers
+// are equal when they have the same value.
+func assertCoffeeModel(t *testing.T, got, expected coffeeResourceModel) {
+	t.Helper()
+	assertResourceModel(t, NewCoffeeResource(), got, expected)
+}
+
+// coffeeModelValue encodes the model with the current coffee schema.
+func coffeeModelValue(t *testing.T, model coffeeResourceModel) tftypes.Value {
+	t.Helper()
+	return resourceModelValue(t, NewCoffeeResource(), model)
+}
+
+func TestCoffeeResourceUpgradeStateV0(t *testing.T) {
+	tests := map[string]struct {
+		state    string
+		expected coffeeResourceModel
+	}{
+		"full": {
+			state: `{
+				"id": "10",
+				"name": "Terraspiced Latte",
+				"teaser": "Exclusively for techdays",
+				"collection": "Foundations",
+				"origin": "Summer 2024",
+				"color": "#444",
+				"description": "",
+				"price": 150,
+				"image": "/terraform.png",
+				"on_create_failure": "rollback",
+				"ingredients": [
+					{"ingredient_id": 1, "name": "Espresso", "quantity": 40, "unit": "ml"},
+					{"ingredient_id": 2, "name": "Steamed Milk", "quantity": 100, "unit": "ml"}
+				]
+			}`,
+			expected: coffeeResourceModel{
+				ID:              types.StringValue("10"),
+				Name:            types.StringValue("Terraspiced Latte"),
+				Teaser:          types.StringValue("Exclusively for techdays"),
+				Collection:      types.StringValue("Foundations"),
+				Origin:          types.StringValue("Summer 2024"),
+				Color:           types.StringValue("#444"),
+				Description:     types.StringValue(""),
+				Price:           moneyValue(hashicups.MoneyFromUnits(150)),
+				Currency:        types.StringValue("USD"),
+				Image:           types.StringValue("/terraform.png"),
+				OnCreateFailure: types.StringValue("rollback"),
+				Ingredients: map[string]ingredientModel{
+					"Espresso": {
+						IngredientID: types.Int64Value(1),
+						Quantity:     types.Float64Value(40),
+						Unit:         types.StringValue("ml"),
+					},
+					"Steamed Milk": {
+						IngredientID: types.Int64Value(2),
+						Quantity:     types.Float64Value(100),
+						Unit:         types.StringValue("ml"),
+					},
+				},
+			},
+		},
+		"minimal": {
+			state: `{
+				"id": "11",
+				"name": "Plain Drip",
+				"teaser": null,
+				"collection": null,
+				"origin": null,
+				"color": null,
+				"description": null,
+				"price": 0,
+				"image": null,
+				"on_create_failure": null,
+				"ingredients": null
+			}`,
+			expected: coffeeResourceModel{
+				ID:              types.StringValue("11"),
+				Name:            types.StringValue("Plain Drip"),
+				Teaser:          types.StringNull(),
+				Collection:      types.StringNull(),
+				Origin:          types.StringNull(),
+				Color:           types.StringNull(),
+				Description:     types.StringNull(),
+				Price:           moneyValue(hashicups.MoneyFromUnits(0)),
+				Currency:        types.StringValue("USD"),
+				Image:           types.StringNull(),
+				OnCreateFailure: types.StringNull(),
+			},
+		},
+		// State written before on_create_failure existed
+		"without later attributes": {
+			state: `{
+				"id": "12",
+				"name": "Vintage Mocha",
+				"teaser": "From the first release",
+				"collection": null,
+				"origin": null,
+				"color": null,
+				"description": null,
+				"price": 200,
+				"image": null,
+				"ingredients": [
+					{"ingredient_id": 3, "name": "Cocoa", "quantity": 5, "unit": "g"}
+				]
+			}`,
+			expected: coffeeResourceModel{
+				ID:              types.StringValue("12"),
+				Name:            types.StringValue("Vintage Mocha"),
+				Teaser:          types.StringValue("From the first release"),
+				Collection:      types.StringNull(),
+				Origin:          types.StringNull(),
+				Color:           types.StringNull(),
+				Description:     types.StringNull(),
+				Price:           moneyValue(hashicups.MoneyFromUnits(200)),
+				Currency:        types.StringValue("USD"),
+				Image:           types.StringNull(),
+				OnCreateFailure: types.StringNull(),
+				Ingredients: map[string]ingredientModel{
+					"Cocoa": {
+						IngredientID: types.Int64Value(3),
+						Quantity:     types.Float64Value(5),
+						Unit:         types.StringValue("g"),
+					},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assertCoffeeModel(t, upgradeCoffeeState(t, 0, test.state), test.expected)
+		})
+	}
+}
+
+func TestCoffeeResourceUpgradeStateV1(t *testing.T) {
+	coffee := func(ingredients map[string]ingredientModel) coffeeResourceModel {
+		return coffeeResourceModel{
+			ID:              types.StringValue("14"),
+			Name:            types.StringValue("Listed Latte"),
+			Teaser:          types.StringNull(),
+			Collection:      types.StringNull(),
+			Origin:          types.StringNull(),
+			Color:           types.StringNull(),
+			Description:     types.StringNull(),
+			Price:           moneyValue(hashicups.MoneyFromCents(425)),
+			Currency:        types.StringValue("EUR"),
+			Image:           types.StringNull(),
+			OnCreateFailure: types.StringNull(),
+			Ingredients:     ingredients,
+		}
+	}
+	state := func(ingredients string) string {
+		return `{
+			"id": "14",
+			"name": "Listed Latte",
+			"teaser": null,
+			"collection": null,
+			"origin": null,
+			"color": null,
+			"description": null,
+			"price": 4.25,
+			"currency": "EUR",
+			"image": null,
+			"on_create_failure": null,
+			"ingredients": ` + ingredients + `
+		}`
+	}
+
+	tests := map[string]struct {
+		ingredients string
+		expected    map[string]ingredientModel
+	}{
+		"list": {
+			ingredients: `[
+				{"ingredient_id": 2, "name": "Steamed Milk", "quantity": 100, "unit": "ml"},
+				{"ingredient_id": 1, "name": "Espresso", "quantity": 1.5, "unit": "shot"}
+			]`,
+			expected: map[string]ingredientModel{
+				"Espresso": {
+					IngredientID: types.Int64Value(1),
+					Quantity:     types.Float64Value(1.5),
+					Unit:         types.StringValue("shot"),
+				},
+				"Steamed Milk": {
+					IngredientID: types.Int64Value(2),
+					Quantity:     types.Float64Value(100),
+					Unit:         types.StringValue("ml"),
+				},
+			},
+		},
+		// The API keeps one ingredient per name, the last one written.
+		"duplicate names": {
+			ingredients: `[
+				{"ingredient_id": 1, "name": "Espresso", "quantity": 40, "unit": "ml"},
+				{"ingredient_id": 1, "name": "Espresso", "quantity": 60, "unit": "ml"}
+			]`,
+			expected: map[string]ingredientModel{
+				"Espresso": {
+					IngredientID: types.Int64Value(1),
+					Quantity:     types.Float64Value(60),
+					Unit:         types.StringValue("ml"),
+				},
+			},
+		},
+		// A failed create leaves ingredients without an ID.
+		"unknown ingredient ID": {
+			ingredients: `[
+				{"ingredient_id": null, "name": "Cocoa", "quantity": 7.5, "unit": "g"}
+			]`,
+			expected: map[string]ingredientModel{
+				"Cocoa": {
+					IngredientID: types.Int64Null(),
+					Quantity:     types.Float64Value(7.5),
+					Unit:         types.StringValue("g"),
+				},
+			},
+		},
+		"empty list": {
+			ingredients: `[]`,
+			expected:    map[string]ingredientModel{},
+		},
+		"null": {
+			ingredients: `null`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assertCoffeeModel(t, upgradeCoffeeState(t, 1, state(test.ingredients)), coffee(test.expected))
+		})
+	}
+}
+
+func TestCoffeeResourceUpgradeStateCurrent(t *testing.T) {
+	// State of the current version passes through unchanged.
+	got := upgradeCoffeeState(t, 2, `{
+		"id": "13",
+		"name": "Current Cortado",
+		"teaser": null,
+		"collection": null,
+		"origin": null,
+		"color": null,
+		"description": null,
+		"price": 3.5,
+		"currency": "EUR",
+		"image": null,
+		"on_create_failure": null,
+		"ingredients": {
+			"Espresso": {"ingredient_id": 1, "quantity": 40, "unit": "ml"}
+		}
+	}`)
+
+	assertCoffeeModel(t, got, coffeeResourceModel{
+		ID:              types.StringValue("13"),
+		Name:            types.StringValue("Current Cortado"),
+		Teaser:          types.StringNull(),
+		Collection:      types.StringNull(),
+		Origin:          types.StringNull(),
+		Color:           types.StringNull(),
+		Description:     types.StringNull(),
+		Price:           moneyValue(hashicups.MoneyFromCents(350)),
+		Currency:        types.StringValue("EUR"),
+		Image:           types.StringNull(),
+		OnCreateFailure: types.StringNull(),
+		Ingredients: map[string]ingredientModel{
+			"Espresso": {
+				IngredientID: types.Int64Value(1),
+				Quantity:     types.Float64Value(40),
+				Unit:         types.StringValue("ml"),
+			},
+		},
+	})
+}
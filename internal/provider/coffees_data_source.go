@@ -1,19 +1,30 @@
 package provider
 
 import (
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp-demoapp/hashicups-client-go"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ datasource.DataSource              = &coffeesDataSource{}
-	_ datasource.DataSourceWithConfigure = &coffeesDataSource{}
+	_ datasource.DataSource                   = &coffeesDataSource{}
+	_ datasource.DataSourceWithConfigure      = &coffeesDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &coffeesDataSource{}
 )
 
 // NewCoffeesDataSource is a helper function to simplify the provider implementation.
@@ -28,26 +39,16 @@ type coffeesDataSource struct {
 
 // coffeesDataSourceModel maps the data source schema data.
 type coffeesDataSourceModel struct {
-	Coffees []coffeesModel `tfsdk:"coffees"`
-	ID      types.String   `tfsdk:"id"`
-}
-
-// coffeesModel maps coffees schema data.
-type coffeesModel struct {
-	ID          types.Int64               `tfsdk:"id"`
-	Name        types.String              `tfsdk:"name"`
-	Teaser      types.String              `tfsdk:"teaser"`
-	Price       types.Float64             `tfsdk:"price"`
-	Image       types.String              `tfsdk:"image"`
-	Ingredients []coffeesIngredientsModel `tfsdk:"ingredients"`
-}
-
-// coffeesIngredientsModel maps coffee ingredients data.
-type coffeesIngredientsModel struct {
-	IngredientId types.Int64  `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Quantity     types.Int64  `tfsdk:"quantity"`
-	Unit         types.String `tfsdk:"unit"`
+	NameRegex     types.String  `tfsdk:"name_regex"`
+	Collection    types.String  `tfsdk:"collection"`
+	Origin        types.String  `tfsdk:"origin"`
+	MinPrice      types.Number  `tfsdk:"min_price"`
+	MaxPrice      types.Number  `tfsdk:"max_price"`
+	HasIngredient types.String  `tfsdk:"has_ingredient"`
+	SortBy        types.String  `tfsdk:"sort_by"`
+	IDs           []types.Int64 `tfsdk:"ids"`
+	Coffees       []coffeeModel `tfsdk:"coffees"`
+	ID            types.String  `tfsdk:"id"`
 }
 
 // Metadata returns the data source type name.
@@ -58,71 +59,98 @@ func (d *coffeesDataSource) Metadata(_ context.Context, req datasource.MetadataR
 // Schema defines the schema for the data source.
 func (d *coffeesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Fetches the list of coffees.",
+		Description: "Fetches the list of coffees, optionally filtered and sorted.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Placeholder identifier attribute.",
+				Description: "Identifier derived from the filter and sort arguments.",
+				Computed:    true,
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Regular expression the coffee name must match.",
+				Optional:    true,
+				Validators:  []validator.String{validRegex()},
+			},
+			"collection": schema.StringAttribute{
+				Description: "Collection the coffee must belong to.",
+				Optional:    true,
+			},
+			"origin": schema.StringAttribute{
+				Description: "Origin the coffee must have.",
+				Optional:    true,
+			},
+			"min_price": schema.NumberAttribute{
+				Description: "Lowest price of the coffee, inclusive.",
+				Optional:    true,
+				Validators:  []validator.Number{money()},
+			},
+			"max_price": schema.NumberAttribute{
+				Description: "Highest price of the coffee, inclusive.",
+				Optional:    true,
+				Validators:  []validator.Number{money()},
+			},
+			"has_ingredient": schema.StringAttribute{
+				Description: "Name of an ingredient the coffee must contain, compared case-insensitively.",
+				Optional:    true,
+			},
+			"sort_by": schema.StringAttribute{
+				Description: "Order of the coffees, one of id, name or price. Defaults to id. Coffees with the same name or price are ordered by id.",
+				Optional:    true,
+				Validators:  []validator.String{stringOneOf(coffeesSortKeys...)},
+			},
+			"ids": schema.ListAttribute{
+				Description: "Numeric identifiers of the coffees, in the same order as coffees.",
+				ElementType: types.Int64Type,
 				Computed:    true,
 			},
 			"coffees": schema.ListNestedAttribute{
-				Description: "List of coffees.",
+				Description: "List of coffees matching every filter.",
 				Computed:    true,
 				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"id": schema.Int64Attribute{
-							Description: "Numeric identifier of the coffee.",
-							Computed:    true,
-						},
-						"name": schema.StringAttribute{
-							Description: "Product name of the coffee.",
-							Computed:    true,
-						},
-						"teaser": schema.StringAttribute{
-							Description: "Fun tagline for the coffee.",
-							Computed:    true,
-						},
-						"price": schema.Float64Attribute{
-							Description: "Suggested cost of the coffee.",
-							Computed:    true,
-						},
-						"image": schema.StringAttribute{
-							Description: "URI for an image of the coffee.",
-							Computed:    true,
-						},
-						"ingredients": schema.ListNestedAttribute{
-							Description: "List of ingredients in the coffee.",
-							Computed:    true,
-							NestedObject: schema.NestedAttributeObject{
-								Attributes: map[string]schema.Attribute{
-									"id": schema.Int64Attribute{
-										Description: "Numeric identifier of the coffee ingredient.",
-										Computed:    true,
-									},
-									"name": schema.StringAttribute{
-										Description: "Name of the coffee ingredient.",
-										Computed:    true,
-									},
-									"quantity": schema.Int64Attribute{
-										Description: "Quantity of the coffee ingredient.",
-										Computed:    true,
-									},
-									"unit": schema.StringAttribute{
-										Description: "Quantity of the coffee ingredient.",
-										Computed:    true,
-									},
-								},
-							},
-						},
-					},
+					Attributes: coffeeDataSourceAttributes(),
 				},
 			},
 		},
 	}
 }
 
+// coffeesSortKeys are the values of sort_by.
+var coffeesSortKeys = []string{"id", "name", "price"}
+
+// ValidateConfig checks that the price range is not empty.
+func (d *coffeesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config coffeesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.MinPrice.IsNull() || config.MinPrice.IsUnknown() || config.MaxPrice.IsNull() || config.MaxPrice.IsUnknown() {
+		return
+	}
+	if config.MinPrice.ValueBigFloat().Cmp(config.MaxPrice.ValueBigFloat()) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_price"),
+			"Invalid Price Range",
+			fmt.Sprintf("The max_price of %s is lower than the min_price of %s, so no coffee could match.",
+				config.MaxPrice.ValueBigFloat().Text('f', -1), config.MinPrice.ValueBigFloat().Text('f', -1)),
+		)
+	}
+}
+
 // Read refreshes the Terraform state with the latest data.
 func (d *coffeesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state coffeesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, diags := newCoffeesFilter(state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	coffees, err := d.client.GetCoffees()
 	if err != nil {
@@ -133,37 +161,177 @@ func (d *coffeesDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	// Map response body to model
-	for _, coffee := range coffees {
-		coffeeState := coffeesModel{
-			ID:     types.Int64Value(int64(coffee.ID)),
-			Name:   types.StringValue(coffee.Name),
-			Teaser: types.StringValue(coffee.Teaser),
-			Price:  types.Float64Value(coffee.Price),
-			Image:  types.StringValue(coffee.Image),
-		}
+	coffees = slices.DeleteFunc(coffees, func(coffee hashicups.Coffee) bool {
+		return !filter.matches(coffee)
+	})
 
-		for _, ingredient := range coffee.Ingredient {
-			coffeeState.Ingredients = append(coffeeState.Ingredients, coffeesIngredientsModel{
-				IngredientId: types.Int64Value(int64(ingredient.ID)),
-				Unit:         types.StringValue(ingredient.Unit),
-				Name:         types.StringValue(ingredient.Name),
-				Quantity:     types.Int64Value(int64(ingredient.Quantity)),
-			})
+	// The coffee list only carries ingredient IDs, so the ingredient names
+	// of the remaining coffees are looked up one coffee at a time.
+	if filter.hasIngredient != nil {
+		var withIngredient []hashicups.Coffee
+		for _, coffee := range coffees {
+			coffeeID := strconv.Itoa(coffee.ID)
+			ingredients, err := d.client.GetCoffeeIngredients(coffeeID)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Read HashiCups Coffee Ingredients",
+					"Could not read the ingredients of HashiCups coffee ID "+coffeeID+": "+err.Error(),
+				)
+				return
+			}
+			if filter.matchesIngredients(ingredients) {
+				withIngredient = append(withIngredient, coffee)
+			}
 		}
+		coffees = withIngredient
+	}
+	sortCoffees(coffees, state.SortBy.ValueString())
 
-		state.Coffees = append(state.Coffees, coffeeState)
+	// Map response body to model
+	state.IDs = []types.Int64{}
+	state.Coffees = []coffeeModel{}
+	for _, coffee := range coffees {
+		state.IDs = append(state.IDs, types.Int64Value(int64(coffee.ID)))
+		state.Coffees = append(state.Coffees, newCoffeeModel(coffee))
 	}
-	state.ID = types.StringValue("placeholder")
+	state.ID = types.StringValue(filter.id(state.SortBy))
 
 	// Set state
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
 
+// coffeesFilter holds the parsed filter arguments of the data source. Unset
+// arguments are nil and match every coffee.
+type coffeesFilter struct {
+	nameRegex     *regexp.Regexp
+	collection    *string
+	origin        *string
+	minPrice      *hashicups.Money
+	maxPrice      *hashicups.Money
+	hasIngredient *string
+}
+
+// newCoffeesFilter parses the filter arguments of config.
+func newCoffeesFilter(config coffeesDataSourceModel) (coffeesFilter, diag.Diagnostics) {
+	var filter coffeesFilter
+	var diags diag.Diagnostics
+
+	if !config.NameRegex.IsNull() {
+		nameRegex, err := regexp.Compile(config.NameRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("name_regex"), "Invalid Name Regex", err.Error())
+		}
+		filter.nameRegex = nameRegex
+	}
+	filter.collection = config.Collection.ValueStringPointer()
+	filter.origin = config.Origin.ValueStringPointer()
+	filter.hasIngredient = config.HasIngredient.ValueStringPointer()
+
+	for _, price := range []struct {
+		value  types.Number
+		name   string
+		target **hashicups.Money
+	}{
+		{config.MinPrice, "min_price", &filter.minPrice},
+		{config.MaxPrice, "max_price", &filter.maxPrice},
+	} {
+		if price.value.IsNull() {
+			continue
+		}
+		m, err := moneyFromNumber(price.value)
+		if err != nil {
+			diags.AddAttributeError(path.Root(price.name), "Invalid Price Filter", err.Error())
+			continue
+		}
+		*price.target = &m
+	}
+
+	return filter, diags
+}
+
+// matches reports whether coffee passes every filter but has_ingredient,
+// which needs the ingredient names and is checked by matchesIngredients.
+func (f coffeesFilter) matches(coffee hashicups.Coffee) bool {
+	switch {
+	case f.nameRegex != nil && !f.nameRegex.MatchString(coffee.Name):
+		return false
+	case f.collection != nil && coffee.Collection != *f.collection:
+		return false
+	case f.origin != nil && coffee.Origin != *f.origin:
+		return false
+	case f.minPrice != nil && coffee.Price.Cmp(*f.minPrice) < 0:
+		return false
+	case f.maxPrice != nil && coffee.Price.Cmp(*f.maxPrice) > 0:
+		return false
+	}
+	return true
+}
+
+// matchesIngredients reports whether the ingredients of a coffee pass the
+// has_ingredient filter. Names are compared case-insensitively.
+func (f coffeesFilter) matchesIngredients(ingredients []hashicups.Ingredient) bool {
+	if f.hasIngredient == nil {
+		return true
+	}
+	return slices.ContainsFunc(ingredients, func(ingredient hashicups.Ingredient) bool {
+		return strings.EqualFold(ingredient.Name, *f.hasIngredient)
+	})
+}
+
+// id derives the data source identifier from the filter and sort arguments,
+// so that the same arguments always give the same identifier. Prices are
+// normalized, so 3.5 and 3.50 give the same identifier.
+func (f coffeesFilter) id(sortBy types.String) string {
+	optional := func(s *string) string {
+		if s == nil {
+			return "null"
+		}
+		return fmt.Sprintf("%q", *s)
+	}
+	price := func(m *hashicups.Money) string {
+		if m == nil {
+			return "null"
+		}
+		return m.String()
+	}
+	var nameRegex *string
+	if f.nameRegex != nil {
+		source := f.nameRegex.String()
+		nameRegex = &source
+	}
+
+	inputs := strings.Join([]string{
+		"name_regex=" + optional(nameRegex),
+		"collection=" + optional(f.collection),
+		"origin=" + optional(f.origin),
+		"min_price=" + price(f.minPrice),
+		"max_price=" + price(f.maxPrice),
+		"has_ingredient=" + optional(f.hasIngredient),
+		"sort_by=" + optional(sortBy.ValueStringPointer()),
+	}, "\n")
+	sum := sha256.Sum256([]byte(inputs))
+	return hex.EncodeToString(sum[:8])
+}
+
+// sortCoffees orders coffees by sortBy, one of coffeesSortKeys, and then by
+// ID. An empty sortBy orders by ID.
+func sortCoffees(coffees []hashicups.Coffee, sortBy string) {
+	slices.SortStableFunc(coffees, func(a, b hashicups.Coffee) int {
+		var c int
+		switch sortBy {
+		case "name":
+			c = strings.Compare(a.Name, b.Name)
+		case "price":
+			c = a.Price.Cmp(b.Price)
+		}
+		return cmp.Or(c, cmp.Compare(a.ID, b.ID))
+	})
+}
+
 // Configure adds the provider configured client to the data source.
 func (d *coffeesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
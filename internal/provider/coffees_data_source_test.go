@@ -1,11 +1,242 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"slices"
 	"testing"
 
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp-demoapp/hashicups-client-go/hashicupstest"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
+// coffeesFilters returns a data source configuration without filters, for
+// tests to set the filters they need.
+func coffeesFilters() coffeesDataSourceModel {
+	return coffeesDataSourceModel{
+		NameRegex:     types.StringNull(),
+		Collection:    types.StringNull(),
+		Origin:        types.StringNull(),
+		MinPrice:      types.NumberNull(),
+		MaxPrice:      types.NumberNull(),
+		HasIngredient: types.StringNull(),
+		SortBy:        types.StringNull(),
+		ID:            types.StringNull(),
+	}
+}
+
+func TestCoffeesDataSourceRead(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	price := func(units int64) types.Number {
+		return types.NumberValue(big.NewFloat(float64(units)))
+	}
+
+	tests := map[string]struct {
+		config func(*coffeesDataSourceModel)
+		ids    []int64
+	}{
+		"unfiltered": {
+			config: func(*coffeesDataSourceModel) {},
+			ids:    []int64{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		"name regex": {
+			config: func(c *coffeesDataSourceModel) { c.NameRegex = types.StringValue("^V") },
+			ids:    []int64{3, 6},
+		},
+		"collection": {
+			config: func(c *coffeesDataSourceModel) { c.Collection = types.StringValue("Foundations") },
+			ids:    []int64{1},
+		},
+		"origin": {
+			config: func(c *coffeesDataSourceModel) { c.Origin = types.StringValue("Fall 2020") },
+			ids:    []int64{8, 9},
+		},
+		"price range": {
+			config: func(c *coffeesDataSourceModel) {
+				c.MinPrice = price(200)
+				c.MaxPrice = price(250)
+			},
+			ids: []int64{1, 3, 6, 7, 8, 9},
+		},
+		"max price": {
+			config: func(c *coffeesDataSourceModel) { c.MaxPrice = price(150) },
+			ids:    []int64{4, 5},
+		},
+		"ingredient": {
+			config: func(c *coffeesDataSourceModel) { c.HasIngredient = types.StringValue("hot water") },
+			ids:    []int64{4, 8},
+		},
+		"sort by name": {
+			config: func(c *coffeesDataSourceModel) { c.SortBy = types.StringValue("name") },
+			ids:    []int64{8, 7, 1, 4, 2, 5, 6, 3, 9},
+		},
+		"sort by price": {
+			config: func(c *coffeesDataSourceModel) { c.SortBy = types.StringValue("price") },
+			ids:    []int64{4, 5, 1, 3, 6, 8, 7, 9, 2},
+		},
+		"combined": {
+			config: func(c *coffeesDataSourceModel) {
+				c.Collection = types.StringValue("Origins")
+				c.HasIngredient = types.StringValue("Steamed Milk")
+				c.SortBy = types.StringValue("price")
+			},
+			ids: []int64{3, 2},
+		},
+		"no match": {
+			config: func(c *coffeesDataSourceModel) { c.Collection = types.StringValue("Seasonal") },
+			ids:    []int64{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := coffeesFilters()
+			test.config(&config)
+
+			var state coffeesDataSourceModel
+			readDataSource(t, &coffeesDataSource{client: client}, config, &state)
+
+			var ids, coffeeIDs []int64
+			for i, id := range state.IDs {
+				ids = append(ids, id.ValueInt64())
+				coffeeIDs = append(coffeeIDs, state.Coffees[i].ID.ValueInt64())
+			}
+			if !slices.Equal(ids, test.ids) || len(state.Coffees) != len(test.ids) || !slices.Equal(coffeeIDs, ids) {
+				t.Errorf("expected coffees %v, got ids %v and coffees %v", test.ids, ids, coffeeIDs)
+			}
+		})
+	}
+}
+
+// The coffee list of the HashiCups API only carries ingredient IDs, unlike
+// the ingredients of a single coffee.
+func TestCoffeesDataSourceReadIngredientIDsOnly(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /coffees", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[
+			{"id": 1, "name": "Americano", "price": 150, "ingredients": [{"ingredient_id": 1}]},
+			{"id": 2, "name": "Latte", "price": 200, "ingredients": [{"ingredient_id": 2}, {"ingredient_id": 3}]}
+		]`)
+	})
+	mux.HandleFunc("GET /coffees/1/ingredients", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[{"ingredient_id": 1, "name": "Hot Water", "quantity": 200, "unit": "ml"}]`)
+	})
+	mux.HandleFunc("GET /coffees/2/ingredients", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[
+			{"ingredient_id": 2, "name": "Espresso", "quantity": 40, "unit": "ml"},
+			{"ingredient_id": 3, "name": "Steamed Milk", "quantity": 150, "unit": "ml"}
+		]`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := &hashicups.Client{HostURL: server.URL, HTTPClient: server.Client()}
+
+	for ingredient, expected := range map[string][]int64{
+		"hot water":    {1},
+		"Steamed Milk": {2},
+		"Cocoa":        {},
+	} {
+		t.Run(ingredient, func(t *testing.T) {
+			config := coffeesFilters()
+			config.HasIngredient = types.StringValue(ingredient)
+
+			var state coffeesDataSourceModel
+			readDataSource(t, &coffeesDataSource{client: client}, config, &state)
+
+			ids := []int64{}
+			for _, id := range state.IDs {
+				ids = append(ids, id.ValueInt64())
+			}
+			if !slices.Equal(ids, expected) {
+				t.Errorf("expected coffees %v, got %v", expected, ids)
+			}
+		})
+	}
+}
+
+func TestCoffeesDataSourceID(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := func(configure func(*coffeesDataSourceModel)) string {
+		config := coffeesFilters()
+		configure(&config)
+
+		var state coffeesDataSourceModel
+		readDataSource(t, &coffeesDataSource{client: client}, config, &state)
+		return state.ID.ValueString()
+	}
+	origins := func(c *coffeesDataSourceModel) { c.Collection = types.StringValue("Origins") }
+
+	if id(origins) != id(origins) {
+		t.Error("expected the same filters to give the same id")
+	}
+	if id(origins) == id(func(c *coffeesDataSourceModel) { c.Origin = types.StringValue("Origins") }) {
+		t.Error("expected the same value in another filter to give another id")
+	}
+	if id(func(*coffeesDataSourceModel) {}) == id(func(c *coffeesDataSourceModel) { c.Collection = types.StringValue("") }) {
+		t.Error("expected an empty filter to give another id than no filter")
+	}
+
+	// The catalog does not affect the id
+	before := id(origins)
+	if err := client.DeleteCoffee("9"); err != nil {
+		t.Fatal(err)
+	}
+	if id(origins) != before {
+		t.Error("expected the id to stay the same when the catalog changes")
+	}
+}
+
+func TestCoffeesDataSourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	d := NewCoffeesDataSource()
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	tests := map[string]struct {
+		min, max types.Number
+		valid    bool
+	}{
+		"range":       {min: types.NumberValue(big.NewFloat(1.5)), max: types.NumberValue(big.NewFloat(2)), valid: true},
+		"single":      {min: types.NumberValue(big.NewFloat(2)), max: types.NumberValue(big.NewFloat(2)), valid: true},
+		"unknown min": {min: types.NumberUnknown(), max: types.NumberValue(big.NewFloat(2)), valid: true},
+		"empty":       {min: types.NumberValue(big.NewFloat(2.5)), max: types.NumberValue(big.NewFloat(2))},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := coffeesFilters()
+			config.MinPrice, config.MaxPrice = test.min, test.max
+			resp := &datasource.ValidateConfigResponse{}
+			d.(datasource.DataSourceWithValidateConfig).ValidateConfig(ctx, datasource.ValidateConfigRequest{
+				Config: testConfig(t, tfsdk.Config{Schema: schemaResp.Schema}, config),
+			}, resp)
+
+			if resp.Diagnostics.HasError() == test.valid {
+				t.Errorf("expected valid %t, got %v", test.valid, resp.Diagnostics)
+			}
+		})
+	}
+}
+
 func TestAccCoffeesDataSource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -16,17 +247,38 @@ func TestAccCoffeesDataSource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					// Verify number of coffees returned
 					resource.TestCheckResourceAttr("data.hashicups_coffees.test", "coffees.#", "9"),
+					resource.TestCheckResourceAttr("data.hashicups_coffees.test", "ids.#", "9"),
 					// Verify the first coffee to ensure all attributes are set
 					resource.TestCheckResourceAttr("data.hashicups_coffees.test", "coffees.0.description", ""),
 					resource.TestCheckResourceAttr("data.hashicups_coffees.test", "coffees.0.id", "1"),
+					resource.TestCheckResourceAttr("data.hashicups_coffees.test", "coffees.0.collection", "Foundations"),
+					resource.TestCheckResourceAttr("data.hashicups_coffees.test", "coffees.0.origin", "Summer 2020"),
+					resource.TestCheckResourceAttr("data.hashicups_coffees.test", "coffees.0.currency", "USD"),
 					resource.TestCheckResourceAttr("data.hashicups_coffees.test", "coffees.0.image", "/hashicorp.png"),
 					resource.TestCheckResourceAttr("data.hashicups_coffees.test", "coffees.0.ingredients.#", "1"),
 					resource.TestCheckResourceAttr("data.hashicups_coffees.test", "coffees.0.ingredients.0.id", "6"),
 					resource.TestCheckResourceAttr("data.hashicups_coffees.test", "coffees.0.name", "HCP Aeropress"),
 					resource.TestCheckResourceAttr("data.hashicups_coffees.test", "coffees.0.price", "200"),
 					resource.TestCheckResourceAttr("data.hashicups_coffees.test", "coffees.0.teaser", "Automation in a cup"),
-					// Verify placeholder id attribute
-					resource.TestCheckResourceAttr("data.hashicups_coffees.test", "id", "placeholder"),
+					// Verify the identifier derived from the arguments
+					resource.TestCheckResourceAttrSet("data.hashicups_coffees.test", "id"),
+				),
+			},
+			// Filter testing
+			{
+				Config: providerConfig + `
+data "hashicups_coffees" "test" {
+  collection     = "Origins"
+  has_ingredient = "Steamed Milk"
+  max_price      = 300
+  sort_by        = "price"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hashicups_coffees.test", "ids.#", "1"),
+					resource.TestCheckResourceAttr("data.hashicups_coffees.test", "ids.0", "3"),
+					resource.TestCheckResourceAttr("data.hashicups_coffees.test", "coffees.#", "1"),
+					resource.TestCheckResourceAttr("data.hashicups_coffees.test", "coffees.0.name", "Vaulatte"),
 				),
 			},
 		},
@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.ObjectTypable                    = ingredientType{}
+	_ basetypes.ObjectValuableWithSemanticEquals = ingredientValue{}
+	_ planmodifier.Map                           = useEquivalentIngredientStateModifier{}
+)
+
+// ingredientType is the type of a hashicups_coffee ingredient. Its values
+// treat quantities in convertible units, such as 10 dl and 1000 ml, as
+// semantically equal.
+type ingredientType struct {
+	basetypes.ObjectType
+}
+
+// newIngredientType returns the ingredient type with its attribute types.
+func newIngredientType() ingredientType {
+	return ingredientType{
+		ObjectType: basetypes.ObjectType{
+			AttrTypes: map[string]attr.Type{
+				"ingredient_id": types.Int64Type,
+				"quantity":      types.Float64Type,
+				"unit":          types.StringType,
+			},
+		},
+	}
+}
+
+func (t ingredientType) Equal(o attr.Type) bool {
+	other, ok := o.(ingredientType)
+	if !ok {
+		return false
+	}
+	return t.ObjectType.Equal(other.ObjectType)
+}
+
+func (t ingredientType) String() string {
+	return "ingredientType"
+}
+
+func (t ingredientType) ValueFromObject(_ context.Context, in basetypes.ObjectValue) (basetypes.ObjectValuable, diag.Diagnostics) {
+	return ingredientValue{ObjectValue: in}, nil
+}
+
+func (t ingredientType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.ObjectType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	objectValue, ok := attrValue.(basetypes.ObjectValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	objectValuable, diags := t.ValueFromObject(ctx, objectValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting ObjectValue to ObjectValuable: %v", diags)
+	}
+	return objectValuable, nil
+}
+
+func (t ingredientType) ValueType(_ context.Context) attr.Value {
+	return ingredientValue{}
+}
+
+// ingredientValue is a value of ingredientType.
+type ingredientValue struct {
+	basetypes.ObjectValue
+}
+
+func (v ingredientValue) Equal(o attr.Value) bool {
+	other, ok := o.(ingredientValue)
+	if !ok {
+		return false
+	}
+	return v.ObjectValue.Equal(other.ObjectValue)
+}
+
+func (v ingredientValue) Type(ctx context.Context) attr.Type {
+	return ingredientType{
+		ObjectType: basetypes.ObjectType{
+			AttrTypes: v.AttributeTypes(ctx),
+		},
+	}
+}
+
+// ObjectSemanticEquals keeps the prior ingredient when the new one has the
+// same ID and describes the same amount, possibly in another unit. This lets
+// the API return its canonical unit without producing a diff.
+func (v ingredientValue) ObjectSemanticEquals(_ context.Context, priorValuable basetypes.ObjectValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	prior, ok := priorValuable.(ingredientValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", priorValuable),
+		)
+		return false, diags
+	}
+
+	if v.IsNull() || v.IsUnknown() || prior.IsNull() || prior.IsUnknown() {
+		return false, diags
+	}
+
+	// A new ingredient ID is a different ingredient, even if it happens to
+	// have the same amount.
+	newID, okNewID := v.Attributes()["ingredient_id"].(types.Int64)
+	priorID, okPriorID := prior.Attributes()["ingredient_id"].(types.Int64)
+	if !okNewID || !okPriorID || !newID.Equal(priorID) {
+		return false, diags
+	}
+
+	return v.sameAmount(prior), diags
+}
+
+// sameAmount reports whether both ingredients have a known quantity and unit
+// describing the same amount, ignoring their IDs.
+func (v ingredientValue) sameAmount(other ingredientValue) bool {
+	if v.IsNull() || v.IsUnknown() || other.IsNull() || other.IsUnknown() {
+		return false
+	}
+
+	attributes, otherAttributes := v.Attributes(), other.Attributes()
+
+	quantity, okQuantity := attributes["quantity"].(types.Float64)
+	otherQuantity, okOtherQuantity := otherAttributes["quantity"].(types.Float64)
+	unit, okUnit := attributes["unit"].(types.String)
+	otherUnit, okOtherUnit := otherAttributes["unit"].(types.String)
+	if !okQuantity || !okOtherQuantity || !okUnit || !okOtherUnit {
+		return false
+	}
+
+	if quantity.IsNull() || quantity.IsUnknown() || otherQuantity.IsNull() || otherQuantity.IsUnknown() ||
+		unit.IsNull() || unit.IsUnknown() || otherUnit.IsNull() || otherUnit.IsUnknown() {
+		return false
+	}
+
+	return sameQuantity(
+		quantity.ValueFloat64(), unit.ValueString(),
+		otherQuantity.ValueFloat64(), otherUnit.ValueString(),
+	)
+}
+
+// useEquivalentIngredientState returns a plan modifier that keeps the prior
+// state of every ingredient whose configured amount is the same as before,
+// possibly in another unit. Semantic equality only applies to values the
+// provider returns, so without it changing 1000 ml to 10 dl in configuration
+// would plan an update.
+func useEquivalentIngredientState() planmodifier.Map {
+	return useEquivalentIngredientStateModifier{}
+}
+
+type useEquivalentIngredientStateModifier struct{}
+
+func (m useEquivalentIngredientStateModifier) Description(_ context.Context) string {
+	return "Keeps the prior state of ingredients whose amount did not change, even when written in another unit."
+}
+
+func (m useEquivalentIngredientStateModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useEquivalentIngredientStateModifier) PlanModifyMap(ctx context.Context, req planmodifier.MapRequest, resp *planmodifier.MapResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	priorElements := req.StateValue.Elements()
+	elements := req.PlanValue.Elements()
+	changed := false
+	for name, element := range elements {
+		planned, okPlanned := element.(ingredientValue)
+		prior, okPrior := priorElements[name].(ingredientValue)
+		if okPlanned && okPrior && planned.sameAmount(prior) {
+			elements[name] = prior
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+
+	planValue, diags := basetypes.NewMapValue(req.PlanValue.ElementType(ctx), elements)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.PlanValue = planValue
+}
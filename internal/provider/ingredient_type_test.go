@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestSameQuantity(t *testing.T) {
+	tests := []struct {
+		quantityA float64
+		unitA     string
+		quantityB float64
+		unitB     string
+		same      bool
+	}{
+		{10, "dl", 1000, "ml", true},
+		{1, "l", 1000, "ml", true},
+		{0.5, "l", 50, "cl", true},
+		{2, "tbsp", 6, "tsp", true},
+		{1, "kg", 1000, "g", true},
+		{7.5, "g", 7500, "mg", true},
+		{1.5, "shot", 1.5, "shot", true},
+		{40, "ml", 40, "ml", true},
+		{10, "dl", 100, "ml", false},
+		{40, "ml", 40, "g", false},
+		{1, "shot", 1, "pump", false},
+		{1, "bucket", 1, "bucket", true},
+		{1, "bucket", 1, "ml", false},
+	}
+
+	for _, test := range tests {
+		if got := sameQuantity(test.quantityA, test.unitA, test.quantityB, test.unitB); got != test.same {
+			t.Errorf("%v %s and %v %s: expected same %t, got %t", test.quantityA, test.unitA, test.quantityB, test.unitB, test.same, got)
+		}
+	}
+}
+
+// testIngredientValue returns an ingredient with the given ID and amount.
+func testIngredientValue(t *testing.T, id attr.Value, quantity float64, unit string) ingredientValue {
+	t.Helper()
+	object, diags := types.ObjectValue(newIngredientType().AttrTypes, map[string]attr.Value{
+		"ingredient_id": id,
+		"quantity":      types.Float64Value(quantity),
+		"unit":          types.StringValue(unit),
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	return ingredientValue{ObjectValue: object}
+}
+
+func TestIngredientValueSemanticEquals(t *testing.T) {
+	ctx := context.Background()
+	value := func(id attr.Value, quantity float64, unit string) ingredientValue {
+		return testIngredientValue(t, id, quantity, unit)
+	}
+
+	tests := map[string]struct {
+		prior    basetypes.ObjectValuable
+		proposed ingredientValue
+		equal    bool
+	}{
+		"canonical unit from the API": {
+			prior:    value(types.Int64Value(1), 10, "dl"),
+			proposed: value(types.Int64Value(1), 1000, "ml"),
+			equal:    true,
+		},
+		"different amount": {
+			prior:    value(types.Int64Value(1), 10, "dl"),
+			proposed: value(types.Int64Value(1), 100, "ml"),
+		},
+		"different ingredient": {
+			prior:    value(types.Int64Value(1), 10, "dl"),
+			proposed: value(types.Int64Value(2), 1000, "ml"),
+		},
+		// After create the planned ID is unknown and must be replaced.
+		"unknown prior ID": {
+			prior:    value(types.Int64Unknown(), 40, "ml"),
+			proposed: value(types.Int64Value(1), 40, "ml"),
+		},
+		"null prior": {
+			prior:    ingredientValue{ObjectValue: types.ObjectNull(newIngredientType().AttrTypes)},
+			proposed: value(types.Int64Value(1), 40, "ml"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			equal, diags := test.proposed.ObjectSemanticEquals(ctx, test.prior)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if equal != test.equal {
+				t.Errorf("expected semantic equality %t, got %t", test.equal, equal)
+			}
+		})
+	}
+
+	_, diags := value(types.Int64Value(1), 40, "ml").ObjectSemanticEquals(ctx, types.ObjectNull(newIngredientType().AttrTypes))
+	if !diags.HasError() {
+		t.Error("expected an error for a prior value of another type")
+	}
+}
+
+func TestUseEquivalentIngredientState(t *testing.T) {
+	ctx := context.Background()
+	ingredients := func(elements map[string]attr.Value) types.Map {
+		return types.MapValueMust(newIngredientType(), elements)
+	}
+
+	state := ingredients(map[string]attr.Value{
+		"Hot Water": testIngredientValue(t, types.Int64Value(1), 10, "dl"),
+		"Espresso":  testIngredientValue(t, types.Int64Value(2), 40, "ml"),
+	})
+
+	tests := map[string]struct {
+		state    types.Map
+		plan     types.Map
+		expected types.Map
+	}{
+		"same amount in another unit": {
+			state: state,
+			plan: ingredients(map[string]attr.Value{
+				"Hot Water": testIngredientValue(t, types.Int64Unknown(), 1000, "ml"),
+				"Espresso":  testIngredientValue(t, types.Int64Unknown(), 40, "ml"),
+			}),
+			expected: state,
+		},
+		"different amount": {
+			state: state,
+			plan: ingredients(map[string]attr.Value{
+				"Hot Water": testIngredientValue(t, types.Int64Unknown(), 100, "ml"),
+				"Espresso":  testIngredientValue(t, types.Int64Unknown(), 4, "cl"),
+			}),
+			expected: ingredients(map[string]attr.Value{
+				"Hot Water": testIngredientValue(t, types.Int64Unknown(), 100, "ml"),
+				"Espresso":  testIngredientValue(t, types.Int64Value(2), 40, "ml"),
+			}),
+		},
+		"new ingredient": {
+			state: state,
+			plan: ingredients(map[string]attr.Value{
+				"Cocoa": testIngredientValue(t, types.Int64Unknown(), 2, "g"),
+			}),
+			expected: ingredients(map[string]attr.Value{
+				"Cocoa": testIngredientValue(t, types.Int64Unknown(), 2, "g"),
+			}),
+		},
+		"create": {
+			state: types.MapNull(newIngredientType()),
+			plan: ingredients(map[string]attr.Value{
+				"Espresso": testIngredientValue(t, types.Int64Unknown(), 40, "ml"),
+			}),
+			expected: ingredients(map[string]attr.Value{
+				"Espresso": testIngredientValue(t, types.Int64Unknown(), 40, "ml"),
+			}),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := &planmodifier.MapResponse{PlanValue: test.plan}
+			useEquivalentIngredientState().PlanModifyMap(ctx, planmodifier.MapRequest{
+				StateValue: test.state,
+				PlanValue:  test.plan,
+			}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			if !resp.PlanValue.Equal(test.expected) {
+				t.Errorf("expected plan %s, got %s", test.expected, resp.PlanValue)
+			}
+		})
+	}
+}
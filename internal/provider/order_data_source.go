@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &orderDataSource{}
+	_ datasource.DataSourceWithConfigure = &orderDataSource{}
+)
+
+// orderIDPattern matches numeric order identifiers.
+var orderIDPattern = regexp.MustCompile(`^[0-9]+$`)
+
+// NewOrderDataSource is a helper function to simplify the provider implementation.
+func NewOrderDataSource() datasource.DataSource {
+	return &orderDataSource{}
+}
+
+// orderDataSource is the data source implementation.
+type orderDataSource struct {
+	client *hashicups.Client
+}
+
+// orderDataSourceModel maps the data source schema data.
+type orderDataSourceModel struct {
+	ID         types.String     `tfsdk:"id"`
+	Items      []orderItemModel `tfsdk:"items"`
+	TotalPrice types.Float64    `tfsdk:"total_price"`
+	ItemCount  types.Int64      `tfsdk:"item_count"`
+	CreatedAt  types.String     `tfsdk:"created_at"`
+	UpdatedAt  types.String     `tfsdk:"updated_at"`
+}
+
+// Metadata returns the data source type name.
+func (d *orderDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_order"
+}
+
+// Schema defines the schema for the data source.
+func (d *orderDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches a single order by its identifier, without managing it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Numeric identifier of the order. The order must be visible to the authenticated user.",
+				Required:    true,
+				Validators:  []validator.String{stringMatches(orderIDPattern, "a numeric order identifier such as 123")},
+			},
+			"items": schema.ListNestedAttribute{
+				Description: "List of items in the order.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: orderItemDataSourceAttributes(),
+				},
+			},
+			"total_price": schema.Float64Attribute{
+				Description: "Sum of the subtotals of every item in the order.",
+				Computed:    true,
+			},
+			"item_count": schema.Int64Attribute{
+				Description: "Number of coffees in the order.",
+				Computed:    true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "RFC 3339 timestamp of the order creation, as reported by the API. Null when the API does not provide it.",
+				Computed:    true,
+			},
+			"updated_at": schema.StringAttribute{
+				Description: "RFC 3339 timestamp of the last order update, as reported by the API. Null when the API does not provide it.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *orderDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state orderDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orderID := state.ID.ValueString()
+	order, err := d.client.GetOrder(orderID)
+	var apiErr *hashicups.APIError
+	switch {
+	case errors.Is(err, hashicups.ErrNotFound):
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Order Not Found",
+			fmt.Sprintf("HashiCups has no order with ID %s. Orders of other users are reported as not found too.", orderID),
+		)
+		return
+	case errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusForbidden || apiErr.StatusCode == http.StatusUnauthorized):
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Order Not Visible",
+			fmt.Sprintf("HashiCups order %s is not visible to the authenticated user. "+
+				"Configure the provider with the credentials of the user who placed the order.", orderID),
+		)
+		return
+	case err != nil:
+		resp.Diagnostics.AddError(
+			"Unable to Read HashiCups Order",
+			"Could not read HashiCups order ID "+orderID+": "+err.Error(),
+		)
+		return
+	}
+
+	// Map response body to model
+	total, count := orderTotals(order.Items)
+	state.Items = []orderItemModel{}
+	for _, item := range order.Items {
+		state.Items = append(state.Items, newOrderItemModel(item))
+	}
+	state.TotalPrice = types.Float64Value(total.Float64())
+	state.ItemCount = types.Int64Value(count)
+	state.CreatedAt = timestampValue(order.CreatedAt)
+	state.UpdatedAt = timestampValue(order.UpdatedAt)
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *orderDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hashicups.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *hashicups.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
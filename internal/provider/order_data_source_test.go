@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp-demoapp/hashicups-client-go/hashicupstest"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// orderLookup returns a data source configuration that looks an order up by
+// id.
+func orderLookup(id string) orderDataSourceModel {
+	return orderDataSourceModel{
+		ID:         types.StringValue(id),
+		TotalPrice: types.Float64Null(),
+		ItemCount:  types.Int64Null(),
+		CreatedAt:  types.StringNull(),
+		UpdatedAt:  types.StringNull(),
+	}
+}
+
+func TestOrderDataSourceRead(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	order, err := client.CreateOrder([]hashicups.OrderItem{
+		{Coffee: hashicups.Coffee{ID: 1}, Quantity: 2},
+		{Coffee: hashicups.Coffee{ID: 2}, Quantity: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var state orderDataSourceModel
+	readDataSource(t, &orderDataSource{client: client}, orderLookup("1"), &state)
+
+	if len(state.Items) != 2 || state.Items[1].Coffee.Name.ValueString() != "Packer Spiced Latte" {
+		t.Fatalf("expected HCP Aeropress and Packer Spiced Latte, got %+v", state.Items)
+	}
+	if state.Items[0].Subtotal.ValueFloat64() != 400 || state.Items[0].Coffee.Collection.ValueString() != "Foundations" {
+		t.Errorf("expected a first item of the Foundations collection with subtotal 400, got %+v", state.Items[0])
+	}
+	if state.TotalPrice.ValueFloat64() != 750 || state.ItemCount.ValueInt64() != 3 {
+		t.Errorf("expected total price 750 for 3 coffees, got %s for %s", state.TotalPrice, state.ItemCount)
+	}
+	if created := order.CreatedAt.Format(time.RFC3339); state.CreatedAt.ValueString() != created || state.UpdatedAt.ValueString() != created {
+		t.Errorf("expected created and updated at %s, got %s and %s", created, state.CreatedAt, state.UpdatedAt)
+	}
+
+	resp := readDataSourceResponse(t, &orderDataSource{client: client}, orderLookup("99"))
+	if errs := resp.Diagnostics.Errors(); len(errs) != 1 || errs[0].Summary() != "Order Not Found" {
+		t.Errorf("expected an Order Not Found error, got %v", resp.Diagnostics)
+	}
+}
+
+func TestOrderDataSourceReadNotVisible(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "Order belongs to another user", http.StatusForbidden)
+	}))
+	defer server.Close()
+	client := &hashicups.Client{HostURL: server.URL, HTTPClient: server.Client()}
+
+	resp := readDataSourceResponse(t, &orderDataSource{client: client}, orderLookup("1"))
+	if errs := resp.Diagnostics.Errors(); len(errs) != 1 || errs[0].Summary() != "Order Not Visible" {
+		t.Errorf("expected an Order Not Visible error, got %v", resp.Diagnostics)
+	}
+}
+
+func TestAccOrderDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "hashicups_order" "test" {
+  items = [
+    {
+      coffee = {
+        id = 1
+      }
+      quantity = 2
+    },
+    {
+      coffee = {
+        id = 2
+      }
+      quantity = 1
+    },
+  ]
+}
+
+data "hashicups_order" "test" {
+  id = hashicups_order.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify the order placed above is read
+					resource.TestCheckResourceAttrPair("data.hashicups_order.test", "id", "hashicups_order.test", "id"),
+					resource.TestCheckResourceAttrPair("data.hashicups_order.test", "created_at", "hashicups_order.test", "created_at"),
+					// Verify items and totals of the order
+					resource.TestCheckResourceAttr("data.hashicups_order.test", "item_count", "3"),
+					resource.TestCheckResourceAttr("data.hashicups_order.test", "total_price", "750"),
+					resource.TestCheckResourceAttr("data.hashicups_order.test", "items.#", "2"),
+					resource.TestCheckResourceAttr("data.hashicups_order.test", "items.0.subtotal", "400"),
+					resource.TestCheckResourceAttr("data.hashicups_order.test", "items.0.coffee.name", "HCP Aeropress"),
+					resource.TestCheckResourceAttr("data.hashicups_order.test", "items.1.coffee.name", "Packer Spiced Latte"),
+				),
+			},
+			// Missing order testing
+			{
+				Config: providerConfig + `
+data "hashicups_order" "missing" {
+  id = "999999"
+}
+`,
+				ExpectError: regexp.MustCompile(`Order Not Found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &orderItemResource{}
+	_ resource.ResourceWithConfigure   = &orderItemResource{}
+	_ resource.ResourceWithImportState = &orderItemResource{}
+)
+
+// NewOrderItemResource is a helper function to simplify the provider implementation.
+func NewOrderItemResource() resource.Resource {
+	return &orderItemResource{}
+}
+
+// orderItemResource is the resource implementation.
+type orderItemResource struct {
+	client *hashicups.Client
+}
+
+// orderItemResourceModel maps the resource schema data.
+type orderItemResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	OrderID  types.String `tfsdk:"order_id"`
+	CoffeeID types.Int64  `tfsdk:"coffee_id"`
+	Quantity types.Int64  `tfsdk:"quantity"`
+}
+
+// Metadata returns the resource type name.
+func (r *orderItemResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_order_item"
+}
+
+// Schema defines the schema for the resource.
+func (r *orderItemResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a single item of an existing order, leaving the other items untouched.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the order item in the form `order_id/coffee_id`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"order_id": schema.StringAttribute{
+				Description: "Numeric identifier of the order the item belongs to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"coffee_id": schema.Int64Attribute{
+				Description: "Numeric identifier of the ordered coffee.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"quantity": schema.Int64Attribute{
+				Description: "Count of this coffee in the order.",
+				Required:    true,
+			},
+		},
+	}
+}
+
+// Create a new resource.
+func (r *orderItemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan orderItemResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Add the item to the order
+	_, err := r.client.AddOrderItem(plan.OrderID.ValueString(), plan.toHashicups())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating HashiCups Order Item",
+			"Could not add coffee to order "+plan.OrderID.ValueString()+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(orderItemID(plan.OrderID.ValueString(), plan.CoffeeID.ValueInt64()))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read resource information.
+func (r *orderItemResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state orderItemResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Get refreshed order value from HashiCups
+	order, err := r.client.GetOrder(state.OrderID.ValueString())
+	if errors.Is(err, hashicups.ErrNotFound) {
+		// The order was deleted outside of Terraform, taking the item with it
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading HashiCups Order",
+			"Could not read HashiCups order ID "+state.OrderID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The item was removed from the order outside of Terraform
+	itemIndex := slices.IndexFunc(order.Items, func(item hashicups.OrderItem) bool {
+		return int64(item.Coffee.ID) == state.CoffeeID.ValueInt64()
+	})
+	if itemIndex < 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Quantity = types.Int64Value(int64(order.Items[itemIndex].Quantity))
+	state.ID = types.StringValue(orderItemID(state.OrderID.ValueString(), state.CoffeeID.ValueInt64()))
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *orderItemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan orderItemResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Change the quantity of the item only
+	_, err := r.client.UpdateOrderItem(plan.OrderID.ValueString(), plan.toHashicups())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating HashiCups Order Item",
+			"Could not update order item "+plan.ID.ValueString()+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *orderItemResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state orderItemResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remove the item from the order
+	err := r.client.DeleteOrderItem(state.OrderID.ValueString(), int(state.CoffeeID.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting HashiCups Order Item",
+			"Could not delete order item "+state.ID.ValueString()+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *orderItemResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*hashicupsResourceData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *hashicupsResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *orderItemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID has the form order_id/coffee_id
+	orderID, coffeeID, found := strings.Cut(req.ID, "/")
+	coffeeIDValue, err := strconv.ParseInt(coffeeID, 10, 64)
+	if !found || orderID == "" || err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: order_id/coffee_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("order_id"), orderID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("coffee_id"), coffeeIDValue)...)
+}
+
+// toHashicups converts the order item model to its API representation.
+func (m orderItemResourceModel) toHashicups() hashicups.OrderItem {
+	return hashicups.OrderItem{
+		Coffee: hashicups.Coffee{
+			ID: int(m.CoffeeID.ValueInt64()),
+		},
+		Quantity: int(m.Quantity.ValueInt64()),
+	}
+}
+
+// orderItemID returns the resource identifier of the item for a coffee in an
+// order.
+func orderItemID(orderID string, coffeeID int64) string {
+	return orderID + "/" + strconv.FormatInt(coffeeID, 10)
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp-demoapp/hashicups-client-go/hashicupstest"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestOrderItemResourceRead(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	order, err := client.CreateOrder([]hashicups.OrderItem{{Coffee: hashicups.Coffee{ID: 1}, Quantity: 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderID := strconv.Itoa(order.ID)
+
+	read := func(t *testing.T, coffeeID int64) *fwresource.ReadResponse {
+		t.Helper()
+		ctx := context.Background()
+		r := &orderItemResource{client: client}
+		schemaResp := &fwresource.SchemaResponse{}
+		r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: resourceModelValue(t, r, orderItemResourceModel{
+			ID:       types.StringValue(orderItemID(orderID, coffeeID)),
+			OrderID:  types.StringValue(orderID),
+			CoffeeID: types.Int64Value(coffeeID),
+			Quantity: types.Int64Value(1),
+		})}
+		resp := &fwresource.ReadResponse{State: state}
+		r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		return resp
+	}
+
+	var got orderItemResourceModel
+	if diags := read(t, 1).State.Get(context.Background(), &got); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got.Quantity.ValueInt64() != 3 {
+		t.Errorf("expected quantity 3, got %s", got.Quantity)
+	}
+
+	if resp := read(t, 2); !resp.State.Raw.IsNull() {
+		t.Errorf("expected an item missing from the order to be removed, got %v", resp.State.Raw)
+	}
+
+	if err := client.DeleteOrder(orderID); err != nil {
+		t.Fatal(err)
+	}
+	if resp := read(t, 1); !resp.State.Raw.IsNull() {
+		t.Errorf("expected the item of a deleted order to be removed, got %v", resp.State.Raw)
+	}
+}
+
+func TestAccOrderItemResource(t *testing.T) {
+	// The public HashiCups API has no order item endpoints
+	server := hashicupstest.NewServer()
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server) + `
+resource "hashicups_order" "test" {
+  items = [
+    {
+      coffee = {
+        id = 1
+      }
+      quantity = 2
+    },
+  ]
+
+  lifecycle {
+    ignore_changes = [items]
+  }
+}
+
+resource "hashicups_order_item" "test" {
+  order_id  = hashicups_order.test.id
+  coffee_id = 2
+  quantity  = 1
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("hashicups_order_item.test", "order_id", "hashicups_order.test", "id"),
+					resource.TestCheckResourceAttr("hashicups_order_item.test", "coffee_id", "2"),
+					resource.TestCheckResourceAttr("hashicups_order_item.test", "quantity", "1"),
+					resource.TestCheckResourceAttrSet("hashicups_order_item.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "hashicups_order_item.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccProviderConfig(server) + `
+resource "hashicups_order" "test" {
+  items = [
+    {
+      coffee = {
+        id = 1
+      }
+      quantity = 2
+    },
+  ]
+
+  lifecycle {
+    ignore_changes = [items]
+  }
+}
+
+resource "hashicups_order_item" "test" {
+  order_id  = hashicups_order.test.id
+  coffee_id = 2
+  quantity  = 3
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hashicups_order_item.test", "quantity", "3"),
+				),
+			},
+			// Invalid import identifier
+			{
+				ResourceName:  "hashicups_order_item.test",
+				ImportState:   true,
+				ImportStateId: "123",
+				ExpectError:   regexp.MustCompile("Unexpected Import Identifier"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -3,23 +3,30 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &orderResource{}
-	_ resource.ResourceWithConfigure   = &orderResource{}
-	_ resource.ResourceWithImportState = &orderResource{}
+	_ resource.Resource                   = &orderResource{}
+	_ resource.ResourceWithConfigure      = &orderResource{}
+	_ resource.ResourceWithImportState    = &orderResource{}
+	_ resource.ResourceWithModifyPlan     = &orderResource{}
+	_ resource.ResourceWithValidateConfig = &orderResource{}
+	_ resource.ResourceWithUpgradeState   = &orderResource{}
 )
 
 // NewOrderResource is a helper function to simplify the provider implementation.
@@ -30,29 +37,39 @@ func NewOrderResource() resource.Resource {
 // orderResource is the resource implementation.
 type orderResource struct {
 	client *hashicups.Client
+	// maxOrderTotal is the provider max_order_total, nil when unset.
+	maxOrderTotal *hashicups.Money
 }
 
 // orderResourceModel maps the resource schema data.
 type orderResourceModel struct {
 	ID          types.String     `tfsdk:"id"`
 	Items       []orderItemModel `tfsdk:"items"`
+	TotalPrice  types.Float64    `tfsdk:"total_price"`
+	ItemCount   types.Int64      `tfsdk:"item_count"`
+	MaxTotal    types.Number     `tfsdk:"max_total"`
 	LastUpdated types.String     `tfsdk:"last_updated"`
+	CreatedAt   types.String     `tfsdk:"created_at"`
+	UpdatedAt   types.String     `tfsdk:"updated_at"`
 }
 
 // orderItemModel maps order item data.
 type orderItemModel struct {
-	Coffee   orderItemCoffeeModel `tfsdk:"coffee"`
-	Quantity types.Int64          `tfsdk:"quantity"`
+	Coffee   coffeeModel   `tfsdk:"coffee"`
+	Quantity types.Int64   `tfsdk:"quantity"`
+	Subtotal types.Float64 `tfsdk:"subtotal"`
 }
 
-// orderItemCoffeeModel maps coffee order item data.
-type orderItemCoffeeModel struct {
-	ID          types.Int64   `tfsdk:"id"`
-	Name        types.String  `tfsdk:"name"`
-	Teaser      types.String  `tfsdk:"teaser"`
-	Description types.String  `tfsdk:"description"`
-	Price       types.Float64 `tfsdk:"price"`
-	Image       types.String  `tfsdk:"image"`
+// maxBudgetLines is how many of the most expensive items a budget error
+// lists.
+const maxBudgetLines = 3
+
+// budgetLine is a planned order item with a known subtotal.
+type budgetLine struct {
+	index    int
+	coffee   hashicups.Coffee
+	quantity int64
+	subtotal hashicups.Money
 }
 
 // Metadata returns the resource type name.
@@ -63,6 +80,7 @@ func (r *orderResource) Metadata(_ context.Context, req resource.MetadataRequest
 // Schema defines the schema for the resource.
 func (r *orderResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     1,
 		Description: "Manages an order.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -73,9 +91,37 @@ func (r *orderResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"last_updated": schema.StringAttribute{
-				Description: "Timestamp of the last Terraform update of the order.",
+				Description: "RFC 3339 timestamp of the last change to the order items. " +
+					"Taken from updated_at when the API provides it, otherwise the time Terraform last changed the order.",
+				Computed: true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "RFC 3339 timestamp of the order creation, as reported by the API. Null when the API does not provide it.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description: "RFC 3339 timestamp of the last order update, as reported by the API. Null when the API does not provide it.",
 				Computed:    true,
 			},
+			"total_price": schema.Float64Attribute{
+				Description: "Sum of the subtotals of every item in the order.",
+				Computed:    true,
+			},
+			"item_count": schema.Int64Attribute{
+				Description: "Number of coffees in the order.",
+				Computed:    true,
+			},
+			"max_total": schema.NumberAttribute{
+				Description: "Most the order may cost. Plans with a higher total fail. " +
+					"The provider max_order_total also applies.",
+				Optional: true,
+				Validators: []validator.Number{
+					money(),
+				},
+			},
 			"items": schema.ListNestedAttribute{
 				Description: "List of items in the order.",
 				Required:    true,
@@ -85,35 +131,14 @@ func (r *orderResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 							Description: "Count of this item in the order.",
 							Required:    true,
 						},
+						"subtotal": schema.Float64Attribute{
+							Description: "Price of the coffee times the quantity.",
+							Computed:    true,
+						},
 						"coffee": schema.SingleNestedAttribute{
 							Description: "Coffee item in the order.",
 							Required:    true,
-							Attributes: map[string]schema.Attribute{
-								"id": schema.Int64Attribute{
-									Description: "Numeric identifier of the coffee.",
-									Required:    true,
-								},
-								"name": schema.StringAttribute{
-									Description: "Product name of the coffee.",
-									Computed:    true,
-								},
-								"teaser": schema.StringAttribute{
-									Description: "Fun tagline for the coffee.",
-									Computed:    true,
-								},
-								"description": schema.StringAttribute{
-									Description: "Product description of the coffee.",
-									Computed:    true,
-								},
-								"price": schema.Float64Attribute{
-									Description: "Suggested cost of the coffee.",
-									Computed:    true,
-								},
-								"image": schema.StringAttribute{
-									Description: "URI for an image of the coffee.",
-									Computed:    true,
-								},
-							},
+							Attributes:  orderItemCoffeeAttributes(),
 						},
 					},
 				},
@@ -122,6 +147,227 @@ func (r *orderResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 	}
 }
 
+// ValidateConfig checks that every item references its coffee either by ID
+// or by name.
+func (r *orderResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var items types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("items"), &items)...)
+	if resp.Diagnostics.HasError() || items.IsNull() || items.IsUnknown() {
+		return
+	}
+
+	var config []orderItemModel
+	resp.Diagnostics.Append(items.ElementsAs(ctx, &config, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, item := range config {
+		coffeePath := path.Root("items").AtListIndex(i).AtName("coffee")
+		switch {
+		case item.Coffee.ID.IsNull() && item.Coffee.Name.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				coffeePath,
+				"Missing Coffee Reference",
+				"Set either the id or the name of the ordered coffee.",
+			)
+		case !item.Coffee.ID.IsNull() && !item.Coffee.Name.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				coffeePath,
+				"Conflicting Coffee Reference",
+				"Set only one of the id or the name of the ordered coffee, not both.",
+			)
+		}
+	}
+}
+
+// orderItemCoffeeAttributes returns the schema attributes of the coffee of
+// an order item. The coffee is chosen by either of its id or name.
+func orderItemCoffeeAttributes() map[string]schema.Attribute {
+	attributes := coffeeResourceAttributes()
+	attributes["id"] = schema.Int64Attribute{
+		Description: "Numeric identifier of the coffee. Must be in the HashiCups catalog and ordered at most once per order. " +
+			"Exactly one of id or name must be set.",
+		Optional: true,
+		Computed: true,
+	}
+	attributes["name"] = schema.StringAttribute{
+		Description: "Product name of the coffee, resolved to its id against the HashiCups catalog. " +
+			"The resolved id is kept while the name is unchanged, even if the coffee is renamed in the catalog. " +
+			"Exactly one of id or name must be set.",
+		Optional: true,
+		Computed: true,
+	}
+	return attributes
+}
+
+// ModifyPlan resolves the planned items against the HashiCups catalog and
+// fills in the computed coffee attributes and totals, so the plan shows
+// exactly what will be ordered and what it costs. Without it, an unknown
+// coffee only fails during apply.
+func (r *orderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var items types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("items"), &items)...)
+	if resp.Diagnostics.HasError() || items.IsNull() || items.IsUnknown() {
+		return
+	}
+
+	var plan, config orderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state *orderResourceModel
+	var prior []orderItemModel
+	if !req.State.Raw.IsNull() {
+		state = &orderResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		prior = state.Items
+	}
+
+	coffees, err := r.client.GetCoffees()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read HashiCups Coffees",
+			"Could not read the HashiCups catalog to check the order items: "+err.Error(),
+		)
+		return
+	}
+
+	catalog := make(map[int64]hashicups.Coffee, len(coffees))
+	byName := map[string][]hashicups.Coffee{}
+	for _, coffee := range coffees {
+		catalog[int64(coffee.ID)] = coffee
+		byName[coffee.Name] = append(byName[coffee.Name], coffee)
+	}
+
+	// The totals are only known when every item is
+	var total hashicups.Money
+	var count int64
+	totalKnown, countKnown := true, true
+	var lines []budgetLine
+
+	ordered := map[int64]int{}
+	for i, item := range plan.Items {
+		quantityKnown := !item.Quantity.IsUnknown() && !item.Quantity.IsNull()
+		if quantityKnown {
+			count += item.Quantity.ValueInt64()
+		} else {
+			countKnown = false
+		}
+
+		coffeePath := path.Root("items").AtListIndex(i).AtName("coffee")
+		configured := config.Items[i].Coffee
+		id := configured.ID
+		refPath := coffeePath.AtName("id")
+
+		if !configured.Name.IsNull() {
+			refPath = coffeePath.AtName("name")
+
+			switch name := configured.Name.ValueString(); {
+			case configured.Name.IsUnknown():
+				// Resolved when Terraform plans again during apply
+			case i < len(prior) && prior[i].Coffee.Name.ValueString() == name && !prior[i].Coffee.ID.IsNull():
+				// Keep the coffee the name was resolved to before, so a
+				// catalog rename does not silently swap it.
+				id = prior[i].Coffee.ID
+			case len(byName[name]) == 1:
+				id = types.Int64Value(int64(byName[name][0].ID))
+			case len(byName[name]) == 0:
+				resp.Diagnostics.AddAttributeError(
+					refPath,
+					"Unknown Coffee",
+					fmt.Sprintf("The HashiCups catalog has no coffee named %q.", name),
+				)
+				continue
+			default:
+				resp.Diagnostics.AddAttributeError(
+					refPath,
+					"Ambiguous Coffee Name",
+					fmt.Sprintf("The HashiCups catalog has %d coffees named %q. Reference the coffee by id instead.", len(byName[name]), name),
+				)
+				continue
+			}
+		}
+
+		// Coffees known only after apply are checked by the API
+		if id.IsUnknown() || id.IsNull() {
+			totalKnown = false
+			continue
+		}
+
+		if first, ok := ordered[id.ValueInt64()]; ok {
+			resp.Diagnostics.AddAttributeError(
+				refPath,
+				"Duplicate Order Item",
+				fmt.Sprintf("Coffee %d is already ordered by item %d. Combine both items into one with the total quantity.", id.ValueInt64(), first),
+			)
+			continue
+		}
+		ordered[id.ValueInt64()] = i
+
+		coffee, ok := catalog[id.ValueInt64()]
+		if !ok {
+			resp.Diagnostics.AddAttributeError(
+				refPath,
+				"Unknown Coffee",
+				fmt.Sprintf("The HashiCups catalog has no coffee with ID %d.", id.ValueInt64()),
+			)
+			continue
+		}
+
+		plan.Items[i].Coffee = newCoffeeModel(coffee)
+		if !configured.Name.IsNull() {
+			plan.Items[i].Coffee.Name = configured.Name
+		}
+
+		if !quantityKnown {
+			totalKnown = false
+			continue
+		}
+		subtotal := coffee.Price.Mul(item.Quantity.ValueInt64())
+		plan.Items[i].Subtotal = types.Float64Value(subtotal.Float64())
+		total = total.Add(subtotal)
+		lines = append(lines, budgetLine{index: i, coffee: coffee, quantity: item.Quantity.ValueInt64(), subtotal: subtotal})
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An unknown total is checked when Terraform plans again during apply,
+	// once every value is known.
+	if totalKnown {
+		r.checkBudget(plan, total, lines, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("items"), plan.Items)...)
+	// Update leaves an order with the same items untouched, so its
+	// timestamps stay the same.
+	if state != nil && sameOrderItems(plan.Items, state.Items) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("last_updated"), state.LastUpdated)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("updated_at"), state.UpdatedAt)...)
+	}
+	if totalKnown {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("total_price"), total.Float64())...)
+	}
+	if countKnown {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("item_count"), count)...)
+	}
+}
+
 // Create a new resource.
 func (r *orderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
@@ -155,20 +401,8 @@ func (r *orderResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Map response body to schema and populate Computed attribute values
 	plan.ID = types.StringValue(strconv.Itoa(order.ID))
-	for orderItemIndex, orderItem := range order.Items {
-		plan.Items[orderItemIndex] = orderItemModel{
-			Coffee: orderItemCoffeeModel{
-				ID:          types.Int64Value(int64(orderItem.Coffee.ID)),
-				Name:        types.StringValue(orderItem.Coffee.Name),
-				Teaser:      types.StringValue(orderItem.Coffee.Teaser),
-				Description: types.StringValue(orderItem.Coffee.Description),
-				Price:       types.Float64Value(orderItem.Coffee.Price),
-				Image:       types.StringValue(orderItem.Coffee.Image),
-			},
-			Quantity: types.Int64Value(int64(orderItem.Quantity)),
-		}
-	}
-	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	plan.setItems(order.Items)
+	plan.setTimestamps(order, true)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -199,20 +433,8 @@ func (r *orderResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Overwrite items with refreshed state
-	state.Items = []orderItemModel{}
-	for _, item := range order.Items {
-		state.Items = append(state.Items, orderItemModel{
-			Coffee: orderItemCoffeeModel{
-				ID:          types.Int64Value(int64(item.Coffee.ID)),
-				Name:        types.StringValue(item.Coffee.Name),
-				Teaser:      types.StringValue(item.Coffee.Teaser),
-				Description: types.StringValue(item.Coffee.Description),
-				Price:       types.Float64Value(item.Coffee.Price),
-				Image:       types.StringValue(item.Coffee.Image),
-			},
-			Quantity: types.Int64Value(int64(item.Quantity)),
-		})
-	}
+	state.setItems(order.Items)
+	state.setTimestamps(order, false)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -231,6 +453,19 @@ func (r *orderResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	var state orderResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing to send when only attributes kept by Terraform changed, such
+	// as max_total. ModifyPlan already planned the unchanged timestamps.
+	if sameOrderItems(plan.Items, state.Items) {
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
 	// Generate API request body from plan
 	var hashicupsItems []hashicups.OrderItem
 	for _, item := range plan.Items {
@@ -264,21 +499,8 @@ func (r *orderResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Update resource state with updated items and timestamp
-	plan.Items = []orderItemModel{}
-	for _, item := range order.Items {
-		plan.Items = append(plan.Items, orderItemModel{
-			Coffee: orderItemCoffeeModel{
-				ID:          types.Int64Value(int64(item.Coffee.ID)),
-				Name:        types.StringValue(item.Coffee.Name),
-				Teaser:      types.StringValue(item.Coffee.Teaser),
-				Description: types.StringValue(item.Coffee.Description),
-				Price:       types.Float64Value(item.Coffee.Price),
-				Image:       types.StringValue(item.Coffee.Image),
-			},
-			Quantity: types.Int64Value(int64(item.Quantity)),
-		})
-	}
-	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	plan.setItems(order.Items)
+	plan.setTimestamps(order, true)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -313,18 +535,116 @@ func (r *orderResource) Configure(_ context.Context, req resource.ConfigureReque
 		return
 	}
 
-	client, ok := req.ProviderData.(*hashicups.Client)
+	data, ok := req.ProviderData.(*hashicupsResourceData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *hashicups.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *hashicupsResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.maxOrderTotal = data.maxOrderTotal
+}
+
+// checkBudget adds an error when the order total exceeds the lower of the
+// provider max_order_total and the resource max_total. The error lists the
+// most expensive items, as they are the likeliest mistakes.
+func (r *orderResource) checkBudget(plan orderResourceModel, total hashicups.Money, lines []budgetLine, diags *diag.Diagnostics) {
+	limit, limitPath, limitName := r.maxOrderTotal, path.Root("items"), "the provider max_order_total"
+	if !plan.MaxTotal.IsNull() && !plan.MaxTotal.IsUnknown() {
+		maxTotal, err := moneyFromNumber(plan.MaxTotal)
+		if err != nil {
+			// Reported by the max_total validator
+			return
+		}
+		if limit == nil || maxTotal.Cmp(*limit) < 0 {
+			limit, limitPath, limitName = &maxTotal, path.Root("max_total"), "max_total"
+		}
+	}
+	if limit == nil || total.Cmp(*limit) <= 0 {
+		return
+	}
+
+	slices.SortStableFunc(lines, func(a, b budgetLine) int {
+		return b.subtotal.Cmp(a.subtotal)
+	})
+
+	var detail strings.Builder
+	fmt.Fprintf(&detail, "The order total of %s exceeds %s of %s. The most expensive items are:\n", total, limitName, limit)
+	for _, line := range lines[:min(len(lines), maxBudgetLines)] {
+		fmt.Fprintf(&detail, "\n  - items[%d]: %d x %s at %s = %s", line.index, line.quantity, line.coffee.Name, line.coffee.Price, line.subtotal)
+	}
+
+	diags.AddAttributeError(limitPath, "Order Exceeds Budget", detail.String())
+}
+
+// setTimestamps maps the order timestamps to the model. The API updated_at,
+// when present, is the last_updated value. Otherwise last_updated is the
+// current time when changed is set, and kept as is when not.
+func (m *orderResourceModel) setTimestamps(order *hashicups.Order, changed bool) {
+	m.CreatedAt = timestampValue(order.CreatedAt)
+	m.UpdatedAt = timestampValue(order.UpdatedAt)
+
+	switch {
+	case !m.UpdatedAt.IsNull():
+		m.LastUpdated = m.UpdatedAt
+	case changed:
+		m.LastUpdated = types.StringValue(time.Now().Format(time.RFC3339))
+	}
+}
+
+// timestampValue maps an optional API timestamp to an RFC 3339 string.
+func timestampValue(t *time.Time) types.String {
+	if t == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(t.Format(time.RFC3339))
+}
+
+// sameOrderItems reports whether both item lists order the same quantities
+// of the same coffees, in the same order.
+func sameOrderItems(a, b []orderItemModel) bool {
+	return slices.EqualFunc(a, b, func(a, b orderItemModel) bool {
+		return a.Coffee.ID.Equal(b.Coffee.ID) && a.Quantity.Equal(b.Quantity)
+	})
+}
+
+// setItems maps the order items returned by the API, and the totals derived
+// from them, to the model. An item keeps its prior coffee name while it
+// orders the same coffee, as the name may be the configured reference of a
+// coffee renamed since. ModifyPlan reports renames of coffees referenced by
+// id instead.
+func (m *orderResourceModel) setItems(items []hashicups.OrderItem) {
+	prior := m.Items
+	m.Items = make([]orderItemModel, 0, len(items))
+	for i, item := range items {
+		name := types.StringValue(item.Coffee.Name)
+		if i < len(prior) && prior[i].Coffee.ID.Equal(types.Int64Value(int64(item.Coffee.ID))) &&
+			!prior[i].Coffee.Name.IsNull() && !prior[i].Coffee.Name.IsUnknown() {
+			name = prior[i].Coffee.Name
+		}
+
+		itemState := newOrderItemModel(item)
+		itemState.Coffee.Name = name
+		m.Items = append(m.Items, itemState)
+	}
+
+	total, count := orderTotals(items)
+	m.TotalPrice = types.Float64Value(total.Float64())
+	m.ItemCount = types.Int64Value(count)
+}
+
+// newOrderItemModel maps an order item returned by the API to the model.
+func newOrderItemModel(item hashicups.OrderItem) orderItemModel {
+	return orderItemModel{
+		Coffee:   newCoffeeModel(item.Coffee),
+		Quantity: types.Int64Value(int64(item.Quantity)),
+		Subtotal: types.Float64Value(item.Coffee.Price.Mul(int64(item.Quantity)).Float64()),
+	}
 }
 
 func (r *orderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -1,9 +1,27 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp-demoapp/hashicups-client-go/hashicupstest"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/compare"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
 func TestAccOrderResource(t *testing.T) {
@@ -33,9 +51,16 @@ resource "hashicups_order" "test" {
 					// Verify first coffee item has Computed attributes filled.
 					resource.TestCheckResourceAttr("hashicups_order.test", "items.0.coffee.description", ""),
 					resource.TestCheckResourceAttr("hashicups_order.test", "items.0.coffee.image", "/hashicorp.png"),
+					resource.TestCheckResourceAttr("hashicups_order.test", "items.0.coffee.collection", "Foundations"),
+					resource.TestCheckResourceAttr("hashicups_order.test", "items.0.coffee.origin", "Summer 2020"),
+					resource.TestCheckResourceAttr("hashicups_order.test", "items.0.coffee.ingredients.#", "1"),
 					resource.TestCheckResourceAttr("hashicups_order.test", "items.0.coffee.name", "HCP Aeropress"),
 					resource.TestCheckResourceAttr("hashicups_order.test", "items.0.coffee.price", "200"),
 					resource.TestCheckResourceAttr("hashicups_order.test", "items.0.coffee.teaser", "Automation in a cup"),
+					// Verify totals
+					resource.TestCheckResourceAttr("hashicups_order.test", "items.0.subtotal", "400"),
+					resource.TestCheckResourceAttr("hashicups_order.test", "total_price", "400"),
+					resource.TestCheckResourceAttr("hashicups_order.test", "item_count", "2"),
 					// Verify dynamic values have any value set in the state.
 					resource.TestCheckResourceAttrSet("hashicups_order.test", "id"),
 					resource.TestCheckResourceAttrSet("hashicups_order.test", "last_updated"),
@@ -46,8 +71,8 @@ resource "hashicups_order" "test" {
 				ResourceName:      "hashicups_order.test",
 				ImportState:       true,
 				ImportStateVerify: true,
-				// The last_updated attribute does not exist in the HashiCups
-				// API, therefore there is no value for it during import.
+				// Without updated_at from the HashiCups API, last_updated is
+				// only known to Terraform and has no value after import.
 				ImportStateVerifyIgnore: []string{"last_updated"},
 			},
 			// Update and Read testing
@@ -74,9 +99,806 @@ resource "hashicups_order" "test" {
 					resource.TestCheckResourceAttr("hashicups_order.test", "items.0.coffee.name", "Packer Spiced Latte"),
 					resource.TestCheckResourceAttr("hashicups_order.test", "items.0.coffee.price", "350"),
 					resource.TestCheckResourceAttr("hashicups_order.test", "items.0.coffee.teaser", "Packed with goodness to spice up your images"),
+					// Verify totals updated
+					resource.TestCheckResourceAttr("hashicups_order.test", "items.0.subtotal", "700"),
+					resource.TestCheckResourceAttr("hashicups_order.test", "total_price", "700"),
+					resource.TestCheckResourceAttr("hashicups_order.test", "item_count", "2"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
+
+func TestOrderResourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewOrderResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	one := types.Int64Value(1)
+	coffeePath := func(i int) path.Path {
+		return path.Root("items").AtListIndex(i).AtName("coffee")
+	}
+
+	tests := map[string]struct {
+		items []orderItemModel
+		paths []path.Path
+	}{
+		"by id": {
+			items: []orderItemModel{configuredOrderItem(types.Int64Value(1), types.StringNull(), one)},
+		},
+		"by name": {
+			items: []orderItemModel{configuredOrderItem(types.Int64Null(), types.StringValue("Vaulatte"), one)},
+		},
+		"known after apply": {
+			items: []orderItemModel{
+				configuredOrderItem(types.Int64Unknown(), types.StringNull(), one),
+				configuredOrderItem(types.Int64Null(), types.StringUnknown(), one),
+			},
+		},
+		"missing reference": {
+			items: []orderItemModel{
+				configuredOrderItem(types.Int64Value(1), types.StringNull(), one),
+				configuredOrderItem(types.Int64Null(), types.StringNull(), one),
+			},
+			paths: []path.Path{coffeePath(1)},
+		},
+		"conflicting references": {
+			items: []orderItemModel{
+				configuredOrderItem(types.Int64Value(1), types.StringValue("HCP Aeropress"), one),
+				configuredOrderItem(types.Int64Unknown(), types.StringValue("Vaulatte"), one),
+			},
+			paths: []path.Path{coffeePath(0), coffeePath(1)},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := testConfig(t, tfsdk.Config{Schema: schemaResp.Schema}, configuredOrder(test.items, types.NumberNull()))
+
+			resp := &fwresource.ValidateConfigResponse{}
+			NewOrderResource().(fwresource.ResourceWithValidateConfig).ValidateConfig(ctx, fwresource.ValidateConfigRequest{Config: config}, resp)
+
+			if paths := diagnosticPaths(t, resp.Diagnostics); !slices.EqualFunc(paths, test.paths, path.Path.Equal) {
+				t.Errorf("expected errors at %v, got %v", test.paths, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestOrderResourceModifyPlan(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.AddCoffee(hashicups.Coffee{Name: "Vaulatte", Price: hashicups.MoneyFromUnits(250)})
+
+	byID := func(id types.Int64, quantity types.Int64) orderItemModel {
+		return configuredOrderItem(id, types.StringNull(), quantity)
+	}
+	byName := func(name types.String, quantity types.Int64) orderItemModel {
+		return configuredOrderItem(types.Int64Null(), name, quantity)
+	}
+	ordered := func(id int64, name string) orderItemModel {
+		item := configuredOrderItem(types.Int64Value(id), types.StringValue(name), types.Int64Value(1))
+		item.Subtotal = types.Float64Value(0)
+		return item
+	}
+	one := types.Int64Value(1)
+	coffeePath := func(i int, attribute string) path.Path {
+		return path.Root("items").AtListIndex(i).AtName("coffee").AtName(attribute)
+	}
+	catalogCoffee := func(id int) coffeeModel {
+		coffee, ok := server.Coffee(id)
+		if !ok {
+			t.Fatalf("no coffee %d in the catalog", id)
+		}
+		return newCoffeeModel(coffee)
+	}
+	nomadicano := catalogCoffee(4)
+	renamed := catalogCoffee(4)
+	renamed.Name = types.StringValue("Nomadicano Classic")
+
+	tests := map[string]struct {
+		items     []orderItemModel
+		prior     []orderItemModel
+		paths     []path.Path
+		planned   map[int]coffeeModel
+		subtotals []types.Float64
+		total     types.Float64
+		count     types.Int64
+	}{
+		"catalog coffees": {
+			items:     []orderItemModel{byID(types.Int64Value(1), types.Int64Value(3)), byID(types.Int64Value(2), one)},
+			planned:   map[int]coffeeModel{0: catalogCoffee(1), 1: catalogCoffee(2)},
+			subtotals: []types.Float64{types.Float64Value(600), types.Float64Value(350)},
+			total:     types.Float64Value(950),
+			count:     types.Int64Value(4),
+		},
+		"coffee name": {
+			items:     []orderItemModel{byName(types.StringValue("Nomadicano"), types.Int64Value(2))},
+			planned:   map[int]coffeeModel{0: nomadicano},
+			subtotals: []types.Float64{types.Float64Value(300)},
+			total:     types.Float64Value(300),
+			count:     types.Int64Value(2),
+		},
+		"unchanged name keeps the resolved coffee": {
+			items:   []orderItemModel{byName(types.StringValue("Nomadicano Classic"), one)},
+			prior:   []orderItemModel{ordered(4, "Nomadicano Classic")},
+			planned: map[int]coffeeModel{0: renamed},
+			total:   types.Float64Value(150),
+			count:   one,
+		},
+		"changed name resolves again": {
+			items:   []orderItemModel{byName(types.StringValue("Nomadicano"), one)},
+			prior:   []orderItemModel{ordered(1, "HCP Aeropress")},
+			planned: map[int]coffeeModel{0: nomadicano},
+			total:   types.Float64Value(150),
+			count:   one,
+		},
+		"coffee ID known after apply": {
+			items:     []orderItemModel{byID(types.Int64Value(1), one), byID(types.Int64Unknown(), one)},
+			planned:   map[int]coffeeModel{1: plannedOrderItem(byID(types.Int64Unknown(), one)).Coffee},
+			subtotals: []types.Float64{types.Float64Value(200), types.Float64Unknown()},
+			total:     types.Float64Unknown(),
+			count:     types.Int64Value(2),
+		},
+		"coffee name known after apply": {
+			items:     []orderItemModel{byName(types.StringUnknown(), one)},
+			planned:   map[int]coffeeModel{0: plannedOrderItem(byName(types.StringUnknown(), one)).Coffee},
+			subtotals: []types.Float64{types.Float64Unknown()},
+			total:     types.Float64Unknown(),
+			count:     one,
+		},
+		"quantity known after apply": {
+			items:     []orderItemModel{byID(types.Int64Value(1), one), byID(types.Int64Value(2), types.Int64Unknown())},
+			subtotals: []types.Float64{types.Float64Value(200), types.Float64Unknown()},
+			total:     types.Float64Unknown(),
+			count:     types.Int64Unknown(),
+		},
+		"unknown coffee": {
+			items: []orderItemModel{byID(types.Int64Value(1), one), byID(types.Int64Value(99), one)},
+			paths: []path.Path{coffeePath(1, "id")},
+		},
+		"unknown coffee name": {
+			items: []orderItemModel{byName(types.StringValue("Nomadicano"), one), byName(types.StringValue("nomadicano"), one)},
+			paths: []path.Path{coffeePath(1, "name")},
+		},
+		"ambiguous coffee name": {
+			items: []orderItemModel{byName(types.StringValue("Vaulatte"), one)},
+			paths: []path.Path{coffeePath(0, "name")},
+		},
+		"duplicate coffee": {
+			items: []orderItemModel{byID(types.Int64Value(1), one), byID(types.Int64Value(2), one), byID(types.Int64Value(1), one)},
+			paths: []path.Path{coffeePath(2, "id")},
+		},
+		"duplicate coffee by name": {
+			items: []orderItemModel{byID(types.Int64Value(1), one), byName(types.StringValue("HCP Aeropress"), one)},
+			paths: []path.Path{coffeePath(1, "name")},
+		},
+		"several problems": {
+			items: []orderItemModel{byID(types.Int64Value(98), one), byID(types.Int64Value(99), one), byID(types.Int64Value(98), one)},
+			paths: []path.Path{coffeePath(0, "id"), coffeePath(1, "id"), coffeePath(2, "id")},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := planOrder(t, &orderResource{client: client}, configuredOrder(test.items, types.NumberNull()), test.prior)
+
+			if paths := diagnosticPaths(t, resp.Diagnostics); !slices.EqualFunc(paths, test.paths, path.Path.Equal) {
+				t.Fatalf("expected errors at %v, got %v", test.paths, resp.Diagnostics)
+			}
+			if test.paths != nil {
+				return
+			}
+
+			var got orderResourceModel
+			if diags := resp.Plan.Get(context.Background(), &got); diags.HasError() {
+				t.Fatal(diags)
+			}
+			for i, coffee := range test.planned {
+				if !equalCoffee(got.Items[i].Coffee, coffee) {
+					t.Errorf("item %d: expected planned coffee %+v, got %+v", i, coffee, got.Items[i].Coffee)
+				}
+			}
+			for i, subtotal := range test.subtotals {
+				if !got.Items[i].Subtotal.Equal(subtotal) {
+					t.Errorf("item %d: expected planned subtotal %s, got %s", i, subtotal, got.Items[i].Subtotal)
+				}
+			}
+			if !got.TotalPrice.Equal(test.total) {
+				t.Errorf("expected planned total price %s, got %s", test.total, got.TotalPrice)
+			}
+			if !got.ItemCount.Equal(test.count) {
+				t.Errorf("expected planned item count %s, got %s", test.count, got.ItemCount)
+			}
+		})
+	}
+}
+
+func TestOrderResourceModifyPlanBudget(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item := func(id int64, quantity types.Int64) orderItemModel {
+		return configuredOrderItem(types.Int64Value(id), types.StringNull(), quantity)
+	}
+	limit := func(units int64) *hashicups.Money {
+		m := hashicups.MoneyFromUnits(units)
+		return &m
+	}
+	// 2 x 200 + 1 x 350 + 3 x 150 = 1200
+	items := []orderItemModel{item(1, types.Int64Value(2)), item(2, types.Int64Value(1)), item(4, types.Int64Value(3))}
+
+	tests := map[string]struct {
+		items         []orderItemModel
+		maxOrderTotal *hashicups.Money
+		maxTotal      types.Number
+		path          path.Path
+		detail        string
+	}{
+		"no limits": {
+			items:    items,
+			maxTotal: types.NumberNull(),
+		},
+		"total at the limit": {
+			items:         items,
+			maxOrderTotal: limit(1200),
+			maxTotal:      moneyValue(hashicups.MoneyFromUnits(1200)),
+		},
+		"provider limit": {
+			items:         items,
+			maxOrderTotal: limit(1000),
+			maxTotal:      types.NumberNull(),
+			path:          path.Root("items"),
+			detail: "The order total of 1200.00 exceeds the provider max_order_total of 1000.00. The most expensive items are:\n" +
+				"\n  - items[2]: 3 x Nomadicano at 150.00 = 450.00" +
+				"\n  - items[0]: 2 x HCP Aeropress at 200.00 = 400.00" +
+				"\n  - items[1]: 1 x Packer Spiced Latte at 350.00 = 350.00",
+		},
+		"resource limit": {
+			items:    items,
+			maxTotal: moneyValue(hashicups.MoneyFromCents(119999)),
+			path:     path.Root("max_total"),
+		},
+		"stricter resource limit": {
+			items:         items,
+			maxOrderTotal: limit(1100),
+			maxTotal:      moneyValue(hashicups.MoneyFromUnits(1000)),
+			path:          path.Root("max_total"),
+		},
+		"stricter provider limit": {
+			items:         items,
+			maxOrderTotal: limit(1000),
+			maxTotal:      moneyValue(hashicups.MoneyFromUnits(1100)),
+			path:          path.Root("items"),
+		},
+		"only the most expensive items": {
+			items: []orderItemModel{
+				item(1, types.Int64Value(1)), item(2, types.Int64Value(1)), item(3, types.Int64Value(1)),
+				item(4, types.Int64Value(1)), item(5, types.Int64Value(500)),
+			},
+			maxTotal: moneyValue(hashicups.MoneyFromUnits(1000)),
+			path:     path.Root("max_total"),
+			detail: "The order total of 75900.00 exceeds max_total of 1000.00. The most expensive items are:\n" +
+				"\n  - items[4]: 500 x Terraspresso at 150.00 = 75000.00" +
+				"\n  - items[1]: 1 x Packer Spiced Latte at 350.00 = 350.00" +
+				"\n  - items[0]: 1 x HCP Aeropress at 200.00 = 200.00",
+		},
+		"limit known after apply": {
+			items:         items,
+			maxOrderTotal: limit(1000),
+			maxTotal:      types.NumberUnknown(),
+			path:          path.Root("items"),
+		},
+		"total known after apply": {
+			items:         []orderItemModel{item(1, types.Int64Value(10)), item(2, types.Int64Unknown())},
+			maxOrderTotal: limit(1000),
+			maxTotal:      types.NumberNull(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &orderResource{client: client, maxOrderTotal: test.maxOrderTotal}
+			resp := planOrder(t, r, configuredOrder(test.items, test.maxTotal), nil)
+
+			errors := resp.Diagnostics.Errors()
+			if len(test.path.Steps()) == 0 {
+				if len(errors) > 0 {
+					t.Fatalf("expected no errors, got %v", errors)
+				}
+				return
+			}
+			if len(errors) != 1 {
+				t.Fatalf("expected one error, got %v", errors)
+			}
+			withPath, ok := errors[0].(diag.DiagnosticWithPath)
+			if !ok || !withPath.Path().Equal(test.path) {
+				t.Errorf("expected error at %s, got %v", test.path, errors[0])
+			}
+			if test.detail != "" && errors[0].Detail() != test.detail {
+				t.Errorf("expected detail:\n%s\ngot:\n%s", test.detail, errors[0].Detail())
+			}
+		})
+	}
+}
+
+func TestOrderResourceModifyPlanTimestamps(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ordered := configuredOrderItem(types.Int64Value(1), types.StringValue("HCP Aeropress"), types.Int64Value(2))
+	tests := map[string]struct {
+		items   []orderItemModel
+		updated types.String
+	}{
+		"same items": {
+			items:   []orderItemModel{configuredOrderItem(types.Int64Value(1), types.StringNull(), types.Int64Value(2))},
+			updated: types.StringValue("2025-01-02T15:04:05Z"),
+		},
+		"same items by name": {
+			items:   []orderItemModel{configuredOrderItem(types.Int64Null(), types.StringValue("HCP Aeropress"), types.Int64Value(2))},
+			updated: types.StringValue("2025-01-02T15:04:05Z"),
+		},
+		"new quantity": {
+			items:   []orderItemModel{configuredOrderItem(types.Int64Value(1), types.StringNull(), types.Int64Value(3))},
+			updated: types.StringUnknown(),
+		},
+		"new coffee": {
+			items:   []orderItemModel{configuredOrderItem(types.Int64Value(2), types.StringNull(), types.Int64Value(2))},
+			updated: types.StringUnknown(),
+		},
+		"new item": {
+			items: []orderItemModel{
+				configuredOrderItem(types.Int64Value(1), types.StringNull(), types.Int64Value(2)),
+				configuredOrderItem(types.Int64Value(2), types.StringNull(), types.Int64Value(1)),
+			},
+			updated: types.StringUnknown(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// A new max_total alone must not count as a change of the order
+			config := configuredOrder(test.items, moneyValue(hashicups.MoneyFromUnits(1000)))
+			resp := planOrder(t, &orderResource{client: client}, config, []orderItemModel{ordered})
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+
+			var got orderResourceModel
+			if diags := resp.Plan.Get(context.Background(), &got); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if !got.LastUpdated.Equal(test.updated) {
+				t.Errorf("expected planned last_updated %s, got %s", test.updated, got.LastUpdated)
+			}
+			if !got.UpdatedAt.Equal(test.updated) {
+				t.Errorf("expected planned updated_at %s, got %s", test.updated, got.UpdatedAt)
+			}
+		})
+	}
+}
+
+func TestOrderResourceModelSetTimestamps(t *testing.T) {
+	created := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	updated := time.Date(2025, 1, 2, 15, 4, 5, 0, time.FixedZone("CET", 3600))
+	prior := types.StringValue("2024-12-24T18:00:00Z")
+
+	var model orderResourceModel
+	model.LastUpdated = prior
+	model.setTimestamps(&hashicups.Order{CreatedAt: &created, UpdatedAt: &updated}, false)
+	if model.CreatedAt.ValueString() != "2025-01-01T09:00:00Z" {
+		t.Errorf("expected created_at 2025-01-01T09:00:00Z, got %s", model.CreatedAt)
+	}
+	if model.UpdatedAt.ValueString() != "2025-01-02T15:04:05+01:00" || !model.LastUpdated.Equal(model.UpdatedAt) {
+		t.Errorf("expected updated_at and last_updated 2025-01-02T15:04:05+01:00, got %s and %s", model.UpdatedAt, model.LastUpdated)
+	}
+
+	// Without API timestamps only a change moves last_updated
+	model.LastUpdated = prior
+	model.setTimestamps(&hashicups.Order{}, false)
+	if !model.CreatedAt.IsNull() || !model.UpdatedAt.IsNull() {
+		t.Errorf("expected null API timestamps, got %s and %s", model.CreatedAt, model.UpdatedAt)
+	}
+	if !model.LastUpdated.Equal(prior) {
+		t.Errorf("expected last_updated %s after a refresh, got %s", prior, model.LastUpdated)
+	}
+
+	model.setTimestamps(&hashicups.Order{}, true)
+	if _, err := time.Parse(time.RFC3339, model.LastUpdated.ValueString()); err != nil || model.LastUpdated.Equal(prior) {
+		t.Errorf("expected a new RFC 3339 last_updated after a change, got %s", model.LastUpdated)
+	}
+}
+
+func TestOrderResourceModelSetItems(t *testing.T) {
+	model := orderResourceModel{
+		Items: []orderItemModel{
+			// Configured by a name the coffee no longer has
+			{Coffee: coffeeModel{ID: types.Int64Value(4), Name: types.StringValue("Nomadicano Classic")}},
+			// Replaced by another coffee
+			{Coffee: coffeeModel{ID: types.Int64Value(1), Name: types.StringValue("HCP Aeropress")}},
+		},
+	}
+	model.setItems([]hashicups.OrderItem{
+		{Coffee: hashicups.Coffee{ID: 4, Name: "Nomadicano", Price: hashicups.MoneyFromCents(150)}, Quantity: 3},
+		{Coffee: hashicups.Coffee{ID: 2, Name: "Packer Spiced Latte", Price: hashicups.MoneyFromCents(10)}, Quantity: 1},
+		{Coffee: hashicups.Coffee{ID: 3, Name: "Vaulatte", Price: hashicups.MoneyFromCents(20)}, Quantity: 1},
+	})
+
+	for i, name := range []string{"Nomadicano Classic", "Packer Spiced Latte", "Vaulatte"} {
+		if got := model.Items[i].Coffee.Name.ValueString(); got != name {
+			t.Errorf("item %d: expected name %q, got %q", i, name, got)
+		}
+	}
+	if got := model.Items[0].Subtotal.ValueFloat64(); got != 4.5 {
+		t.Errorf("expected subtotal 4.5, got %v", got)
+	}
+	// Summed in cents, 4.50 + 0.10 + 0.20 is exactly 4.80
+	if got := model.TotalPrice.ValueFloat64(); got != 4.8 {
+		t.Errorf("expected total price 4.8, got %v", got)
+	}
+	if got := model.ItemCount.ValueInt64(); got != 5 {
+		t.Errorf("expected item count 5, got %d", got)
+	}
+}
+
+// configuredOrderItem returns an order item as written in the configuration,
+// with every computed attribute null.
+func configuredOrderItem(id types.Int64, name types.String, quantity types.Int64) orderItemModel {
+	return orderItemModel{
+		Coffee: coffeeModel{
+			ID:          id,
+			Name:        name,
+			Teaser:      types.StringNull(),
+			Collection:  types.StringNull(),
+			Origin:      types.StringNull(),
+			Description: types.StringNull(),
+			Color:       types.StringNull(),
+			Price:       types.Float64Null(),
+			Currency:    types.StringNull(),
+			Image:       types.StringNull(),
+			Ingredients: types.ListNull(coffeeIngredientsType.ElemType),
+		},
+		Quantity: quantity,
+		Subtotal: types.Float64Null(),
+	}
+}
+
+// configuredOrder returns an order as written in the configuration.
+func configuredOrder(items []orderItemModel, maxTotal types.Number) orderResourceModel {
+	return orderResourceModel{
+		ID:          types.StringNull(),
+		Items:       items,
+		TotalPrice:  types.Float64Null(),
+		ItemCount:   types.Int64Null(),
+		MaxTotal:    maxTotal,
+		LastUpdated: types.StringNull(),
+		CreatedAt:   types.StringNull(),
+		UpdatedAt:   types.StringNull(),
+	}
+}
+
+// plannedOrderItem returns the plan Terraform proposes for a configured
+// order item, with every unset computed attribute unknown.
+func plannedOrderItem(item orderItemModel) orderItemModel {
+	if item.Coffee.ID.IsNull() {
+		item.Coffee.ID = types.Int64Unknown()
+	}
+	if item.Coffee.Name.IsNull() {
+		item.Coffee.Name = types.StringUnknown()
+	}
+	item.Coffee.Teaser = types.StringUnknown()
+	item.Coffee.Collection = types.StringUnknown()
+	item.Coffee.Origin = types.StringUnknown()
+	item.Coffee.Description = types.StringUnknown()
+	item.Coffee.Color = types.StringUnknown()
+	item.Coffee.Price = types.Float64Unknown()
+	item.Coffee.Currency = types.StringUnknown()
+	item.Coffee.Image = types.StringUnknown()
+	item.Coffee.Ingredients = types.ListUnknown(coffeeIngredientsType.ElemType)
+	item.Subtotal = types.Float64Unknown()
+	return item
+}
+
+// planOrder runs ModifyPlan for the configured order, updating an existing
+// order with the prior items unless prior is nil.
+func planOrder(t *testing.T, r *orderResource, config orderResourceModel, prior []orderItemModel) *fwresource.ModifyPlanResponse {
+	t.Helper()
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	planned := config
+	planned.Items = nil
+	for _, item := range config.Items {
+		planned.Items = append(planned.Items, plannedOrderItem(item))
+	}
+	planned.ID = types.StringUnknown()
+	planned.TotalPrice = types.Float64Unknown()
+	planned.ItemCount = types.Int64Unknown()
+	planned.LastUpdated = types.StringUnknown()
+	planned.CreatedAt = types.StringUnknown()
+	planned.UpdatedAt = types.StringUnknown()
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	diags := plan.Set(ctx, planned)
+	if prior != nil {
+		existing := configuredOrder(prior, config.MaxTotal)
+		existing.ID = types.StringValue("1")
+		existing.LastUpdated = types.StringValue("2025-01-02T15:04:05Z")
+		existing.CreatedAt = types.StringValue("2025-01-01T09:00:00Z")
+		existing.UpdatedAt = types.StringValue("2025-01-02T15:04:05Z")
+		diags.Append(state.Set(ctx, existing)...)
+	}
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	req := fwresource.ModifyPlanRequest{
+		Config: testConfig(t, tfsdk.Config{Schema: schemaResp.Schema}, config),
+		Plan:   plan,
+		State:  state,
+	}
+	resp := &fwresource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, req, resp)
+	return resp
+}
+
+// diagnosticPaths returns the attribute paths of the error diagnostics.
+func diagnosticPaths(t *testing.T, diags diag.Diagnostics) []path.Path {
+	t.Helper()
+	var paths []path.Path
+	for _, d := range diags.Errors() {
+		withPath, ok := d.(diag.DiagnosticWithPath)
+		if !ok {
+			t.Fatalf("expected diagnostic with attribute path, got %v", d)
+		}
+		paths = append(paths, withPath.Path())
+	}
+	return paths
+}
+
+func TestAccOrderResourceCatalogValidation(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+
+	config := func(items string) string {
+		return testAccProviderConfig(server) + `
+resource "hashicups_order" "test" {
+  items = [` + items + `]
+}
+`
+	}
+	item := func(coffeeID, quantity int) string {
+		return fmt.Sprintf("{\n  coffee = { id = %d }\n  quantity = %d\n},\n", coffeeID, quantity)
+	}
+	itemPath := func(i int, attribute string) tfjsonpath.Path {
+		return tfjsonpath.New("items").AtSliceIndex(i).AtMapKey("coffee").AtMapKey(attribute)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(item(1, 1) + item(99, 1)),
+				ExpectError: regexp.MustCompile("The HashiCups catalog has no coffee with ID 99"),
+			},
+			{
+				Config:      config(item(1, 1) + item(2, 1) + item(1, 2)),
+				ExpectError: regexp.MustCompile("Coffee 1 is already ordered by item 0"),
+			},
+			// The plan shows the coffees that will be ordered
+			{
+				Config: config(item(1, 2) + item(2, 1)),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("hashicups_order.test", itemPath(0, "name"), knownvalue.StringExact("HCP Aeropress")),
+						plancheck.ExpectKnownValue("hashicups_order.test", itemPath(0, "price"), knownvalue.Float64Exact(200)),
+						plancheck.ExpectKnownValue("hashicups_order.test", itemPath(0, "teaser"), knownvalue.StringExact("Automation in a cup")),
+						plancheck.ExpectKnownValue("hashicups_order.test", itemPath(0, "image"), knownvalue.StringExact("/hashicorp.png")),
+						plancheck.ExpectKnownValue("hashicups_order.test", itemPath(1, "name"), knownvalue.StringExact("Packer Spiced Latte")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hashicups_order.test", "items.#", "2"),
+					resource.TestCheckResourceAttr("hashicups_order.test", "items.1.coffee.price", "350"),
+				),
+			},
+			// Changing a coffee shows its new details before apply
+			{
+				Config: config(item(1, 2) + item(3, 1)),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("hashicups_order.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("hashicups_order.test", itemPath(1, "name"), knownvalue.StringExact("Vaulatte")),
+						plancheck.ExpectKnownValue("hashicups_order.test", itemPath(1, "image"), knownvalue.StringExact("/vault.png")),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccOrderResourceBudget(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+
+	config := func(quantity int, maxTotal string) string {
+		return fmt.Sprintf(`
+provider "hashicups" {
+  username        = %q
+  password        = %q
+  host            = %q
+  max_order_total = 1000
+}
+
+resource "hashicups_order" "test" {
+  max_total = %s
+  items = [
+    {
+      coffee = { id = 1 }
+      quantity = %d
+    },
+  ]
+}
+`, hashicupstest.Username, hashicupstest.Password, server.URL, maxTotal, quantity)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(500, "null"),
+				ExpectError: regexp.MustCompile(`exceeds the provider max_order_total of 1000\.00`),
+			},
+			{
+				Config:      config(3, "500"),
+				ExpectError: regexp.MustCompile(`items\[0\]: 3 x HCP Aeropress at 200\.00 = 600\.00`),
+			},
+			{
+				Config: config(2, "500"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hashicups_order.test", "total_price", "400"),
+					resource.TestCheckResourceAttr("hashicups_order.test", "max_total", "500"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccOrderResourceCoffeeName(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+
+	config := testAccProviderConfig(server) + `
+resource "hashicups_order" "test" {
+  items = [
+    {
+      coffee = { name = "Nomadicano" }
+      quantity = 1
+    },
+  ]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hashicups_order.test", "items.0.coffee.id", "4"),
+					resource.TestCheckResourceAttr("hashicups_order.test", "items.0.coffee.name", "Nomadicano"),
+					resource.TestCheckResourceAttr("hashicups_order.test", "items.0.coffee.image", "/nomad.png"),
+				),
+			},
+			// Renaming the coffee and reusing its name for another one keeps
+			// the order on the coffee it was resolved to.
+			{
+				PreConfig: func() {
+					coffee, _ := server.Coffee(4)
+					coffee.Name = "Nomadicano Classic"
+					server.SetCoffee(coffee)
+					server.AddCoffee(hashicups.Coffee{Name: "Nomadicano", Price: hashicups.MoneyFromUnits(175)})
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("hashicups_order.test", "items.0.coffee.id", "4"),
+			},
+			// The API timestamps make last_updated importable
+			{
+				ResourceName:      "hashicups_order.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccOrderResourceTimestamps(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+
+	config := func(quantity int, maxTotal string) string {
+		return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "hashicups_order" "test" {
+  max_total = %s
+  items = [
+    {
+      coffee = { id = 1 }
+      quantity = %d
+    },
+  ]
+}
+`, maxTotal, quantity)
+	}
+
+	sameLastUpdated := statecheck.CompareValue(compare.ValuesSame())
+	changedLastUpdated := statecheck.CompareValue(compare.ValuesDiffer())
+	sameCreatedAt := statecheck.CompareValue(compare.ValuesSame())
+	rfc3339 := regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(Z|[+-]\d{2}:\d{2})$`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(1, "null"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("hashicups_order.test", "last_updated", rfc3339),
+					resource.TestMatchResourceAttr("hashicups_order.test", "created_at", rfc3339),
+					resource.TestCheckResourceAttrPair("hashicups_order.test", "last_updated", "hashicups_order.test", "updated_at"),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					sameLastUpdated.AddStateValue("hashicups_order.test", tfjsonpath.New("last_updated")),
+					changedLastUpdated.AddStateValue("hashicups_order.test", tfjsonpath.New("last_updated")),
+					sameCreatedAt.AddStateValue("hashicups_order.test", tfjsonpath.New("created_at")),
+				},
+			},
+			// A new budget does not touch the remote order
+			{
+				PreConfig: func() { time.Sleep(time.Second) },
+				Config:    config(1, "1000"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("hashicups_order.test", tfjsonpath.New("last_updated"), knownvalue.NotNull()),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					sameLastUpdated.AddStateValue("hashicups_order.test", tfjsonpath.New("last_updated")),
+				},
+			},
+			{
+				PreConfig: func() { time.Sleep(time.Second) },
+				Config:    config(2, "1000"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					changedLastUpdated.AddStateValue("hashicups_order.test", tfjsonpath.New("last_updated")),
+					sameCreatedAt.AddStateValue("hashicups_order.test", tfjsonpath.New("created_at")),
+				},
+			},
+			{
+				ResourceName:            "hashicups_order.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"max_total"},
+			},
+		},
+	})
+}
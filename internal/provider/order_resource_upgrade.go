@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// UpgradeState migrates state written by earlier versions of the schema.
+func (r *orderResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   orderSchemaV0(),
+			StateUpgrader: upgradeOrderStateV0,
+		},
+	}
+}
+
+// orderResourceModelV0 maps the version 0 schema data, which stored
+// last_updated in RFC 850 format and had no API timestamps.
+type orderResourceModelV0 struct {
+	ID          types.String       `tfsdk:"id"`
+	Items       []orderItemModelV0 `tfsdk:"items"`
+	TotalPrice  types.Float64      `tfsdk:"total_price"`
+	ItemCount   types.Int64        `tfsdk:"item_count"`
+	MaxTotal    types.Number       `tfsdk:"max_total"`
+	LastUpdated types.String       `tfsdk:"last_updated"`
+}
+
+// orderItemModelV0 maps an order item of the version 0 schema.
+type orderItemModelV0 struct {
+	Coffee   orderItemCoffeeModelV0 `tfsdk:"coffee"`
+	Quantity types.Int64            `tfsdk:"quantity"`
+	Subtotal types.Float64          `tfsdk:"subtotal"`
+}
+
+// orderItemCoffeeModelV0 maps the coffee of an order item of the version 0
+// schema, before the order exposed every coffee field.
+type orderItemCoffeeModelV0 struct {
+	ID          types.Int64   `tfsdk:"id"`
+	Name        types.String  `tfsdk:"name"`
+	Teaser      types.String  `tfsdk:"teaser"`
+	Description types.String  `tfsdk:"description"`
+	Price       types.Float64 `tfsdk:"price"`
+	Image       types.String  `tfsdk:"image"`
+}
+
+// orderSchemaV0 returns the version 0 schema, used to decode old state.
+// Attributes added later within version 0, such as total_price, decode as
+// null from state written before they existed.
+func orderSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":           schema.StringAttribute{Computed: true},
+			"last_updated": schema.StringAttribute{Computed: true},
+			"total_price":  schema.Float64Attribute{Computed: true},
+			"item_count":   schema.Int64Attribute{Computed: true},
+			"max_total":    schema.NumberAttribute{Optional: true},
+			"items": schema.ListNestedAttribute{
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"quantity": schema.Int64Attribute{Required: true},
+						"subtotal": schema.Float64Attribute{Computed: true},
+						"coffee": schema.SingleNestedAttribute{
+							Required: true,
+							Attributes: map[string]schema.Attribute{
+								"id":          schema.Int64Attribute{Optional: true, Computed: true},
+								"name":        schema.StringAttribute{Optional: true, Computed: true},
+								"teaser":      schema.StringAttribute{Computed: true},
+								"description": schema.StringAttribute{Computed: true},
+								"price":       schema.Float64Attribute{Computed: true},
+								"image":       schema.StringAttribute{Computed: true},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// upgradeOrderStateV0 converts last_updated of version 0 state to RFC 3339.
+// The API timestamps are left null for the next refresh to fill in.
+func upgradeOrderStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior orderResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep values in any other format rather than lose them
+	lastUpdated := prior.LastUpdated
+	if t, err := time.Parse(time.RFC850, prior.LastUpdated.ValueString()); err == nil {
+		lastUpdated = types.StringValue(t.Format(time.RFC3339))
+	}
+
+	// The coffee fields the order gained later stay null until the next
+	// refresh, like they do in version 1 state written before them.
+	var items []orderItemModel
+	for _, item := range prior.Items {
+		items = append(items, orderItemModel{
+			Coffee: coffeeModel{
+				ID:          item.Coffee.ID,
+				Name:        item.Coffee.Name,
+				Teaser:      item.Coffee.Teaser,
+				Collection:  types.StringNull(),
+				Origin:      types.StringNull(),
+				Description: item.Coffee.Description,
+				Color:       types.StringNull(),
+				Price:       item.Coffee.Price,
+				Currency:    types.StringNull(),
+				Image:       item.Coffee.Image,
+				Ingredients: types.ListNull(coffeeIngredientsType.ElemType),
+			},
+			Quantity: item.Quantity,
+			Subtotal: item.Subtotal,
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, orderResourceModel{
+		ID:          prior.ID,
+		Items:       items,
+		TotalPrice:  prior.TotalPrice,
+		ItemCount:   prior.ItemCount,
+		MaxTotal:    prior.MaxTotal,
+		LastUpdated: lastUpdated,
+		CreatedAt:   types.StringNull(),
+		UpdatedAt:   types.StringNull(),
+	})...)
+}
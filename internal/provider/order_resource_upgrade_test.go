@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// upgradedOrderItem is the item of the upgrade test states, with the coffee
+// fields the order gained later left null.
+func upgradedOrderItem() orderItemModel {
+	return orderItemModel{
+		Coffee: coffeeModel{
+			ID:          types.Int64Value(1),
+			Name:        types.StringValue("HCP Aeropress"),
+			Teaser:      types.StringValue("Automation in a cup"),
+			Collection:  types.StringNull(),
+			Origin:      types.StringNull(),
+			Description: types.StringValue(""),
+			Color:       types.StringNull(),
+			Price:       types.Float64Value(200),
+			Currency:    types.StringNull(),
+			Image:       types.StringValue("/hashicorp.png"),
+			Ingredients: types.ListNull(coffeeIngredientsType.ElemType),
+		},
+		Quantity: types.Int64Value(2),
+		Subtotal: types.Float64Null(),
+	}
+}
+
+func TestOrderResourceUpgradeStateV0(t *testing.T) {
+	itemJSON := `{
+		"coffee": {"id": 1, "name": "HCP Aeropress", "teaser": "Automation in a cup", "description": "", "price": 200, "image": "/hashicorp.png"},
+		"quantity": 2
+	}`
+
+	tests := map[string]struct {
+		state       string
+		lastUpdated types.String
+	}{
+		"RFC 850 timestamp": {
+			state:       `{"id": "1", "last_updated": "Thursday, 02-Jan-25 15:04:05 UTC", "items": [` + itemJSON + `]}`,
+			lastUpdated: types.StringValue("2025-01-02T15:04:05Z"),
+		},
+		"other timestamp": {
+			state:       `{"id": "1", "last_updated": "yesterday", "items": [` + itemJSON + `]}`,
+			lastUpdated: types.StringValue("yesterday"),
+		},
+		"imported": {
+			state:       `{"id": "1", "last_updated": null, "items": [` + itemJSON + `]}`,
+			lastUpdated: types.StringNull(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got orderResourceModel
+			upgradeResourceState(t, NewOrderResource(), "hashicups_order", 0, test.state, &got)
+
+			expected := orderResourceModel{
+				ID:          types.StringValue("1"),
+				Items:       []orderItemModel{upgradedOrderItem()},
+				TotalPrice:  types.Float64Null(),
+				ItemCount:   types.Int64Null(),
+				MaxTotal:    types.NumberNull(),
+				LastUpdated: test.lastUpdated,
+				CreatedAt:   types.StringNull(),
+				UpdatedAt:   types.StringNull(),
+			}
+			assertResourceModel(t, NewOrderResource(), got, expected)
+		})
+	}
+}
+
+// State written by version 1 before the order exposed every coffee field
+// decodes the missing coffee attributes as null.
+func TestOrderResourceUpgradeStateV1WithoutCoffeeFields(t *testing.T) {
+	var got orderResourceModel
+	upgradeResourceState(t, NewOrderResource(), "hashicups_order", 1, `{
+		"id": "1",
+		"last_updated": "2025-01-02T15:04:05Z",
+		"created_at": null,
+		"updated_at": null,
+		"items": [{
+			"coffee": {"id": 1, "name": "HCP Aeropress", "teaser": "Automation in a cup", "description": "", "price": 200, "image": "/hashicorp.png"},
+			"quantity": 2
+		}]
+	}`, &got)
+
+	assertResourceModel(t, NewOrderResource(), got, orderResourceModel{
+		ID:          types.StringValue("1"),
+		Items:       []orderItemModel{upgradedOrderItem()},
+		TotalPrice:  types.Float64Null(),
+		ItemCount:   types.Int64Null(),
+		MaxTotal:    types.NumberNull(),
+		LastUpdated: types.StringValue("2025-01-02T15:04:05Z"),
+		CreatedAt:   types.StringNull(),
+		UpdatedAt:   types.StringNull(),
+	})
+}
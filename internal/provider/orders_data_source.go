@@ -0,0 +1,272 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &ordersDataSource{}
+	_ datasource.DataSourceWithConfigure      = &ordersDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &ordersDataSource{}
+)
+
+// NewOrdersDataSource is a helper function to simplify the provider implementation.
+func NewOrdersDataSource() datasource.DataSource {
+	return &ordersDataSource{}
+}
+
+// ordersDataSource is the data source implementation.
+type ordersDataSource struct {
+	client *hashicups.Client
+}
+
+// ordersDataSourceModel maps the data source schema data.
+type ordersDataSourceModel struct {
+	ID            types.String  `tfsdk:"id"`
+	CoffeeID      types.Int64   `tfsdk:"coffee_id"`
+	CreatedAfter  types.String  `tfsdk:"created_after"`
+	CreatedBefore types.String  `tfsdk:"created_before"`
+	Orders        []ordersModel `tfsdk:"orders"`
+}
+
+// ordersModel maps orders schema data.
+type ordersModel struct {
+	ID         types.String     `tfsdk:"id"`
+	Items      []orderItemModel `tfsdk:"items"`
+	TotalPrice types.Float64    `tfsdk:"total_price"`
+	ItemCount  types.Int64      `tfsdk:"item_count"`
+}
+
+// Metadata returns the data source type name.
+func (d *ordersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_orders"
+}
+
+// Schema defines the schema for the data source.
+func (d *ordersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the orders of the authenticated user.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier derived from the filter arguments.",
+				Computed:    true,
+			},
+			"coffee_id": schema.Int64Attribute{
+				Description: "Only return orders containing the coffee with this numeric identifier.",
+				Optional:    true,
+			},
+			"created_after": schema.StringAttribute{
+				Description: "Only return orders created at or after this RFC 3339 timestamp. " +
+					"Orders without a creation timestamp are not returned when set.",
+				Optional:   true,
+				Validators: []validator.String{rfc3339()},
+			},
+			"created_before": schema.StringAttribute{
+				Description: "Only return orders created at or before this RFC 3339 timestamp. " +
+					"Orders without a creation timestamp are not returned when set.",
+				Optional:   true,
+				Validators: []validator.String{rfc3339()},
+			},
+			"orders": schema.ListNestedAttribute{
+				Description: "List of orders.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Numeric identifier of the order.",
+							Computed:    true,
+						},
+						"total_price": schema.Float64Attribute{
+							Description: "Sum of the price of every coffee in the order times its quantity.",
+							Computed:    true,
+						},
+						"item_count": schema.Int64Attribute{
+							Description: "Number of coffees in the order.",
+							Computed:    true,
+						},
+						"items": schema.ListNestedAttribute{
+							Description: "List of items in the order.",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: orderItemDataSourceAttributes(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the creation date range is not empty.
+func (d *ordersDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config ordersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Invalid timestamps are reported by the rfc3339 validator
+	after, errAfter := time.Parse(time.RFC3339, config.CreatedAfter.ValueString())
+	before, errBefore := time.Parse(time.RFC3339, config.CreatedBefore.ValueString())
+	if errAfter == nil && errBefore == nil && after.After(before) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("created_before"),
+			"Invalid Creation Date Range",
+			fmt.Sprintf("The created_before of %s is earlier than the created_after of %s, so no order could match.",
+				config.CreatedBefore.ValueString(), config.CreatedAfter.ValueString()),
+		)
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ordersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ordersDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := hashicups.OrderFilter{
+		CoffeeID: int(state.CoffeeID.ValueInt64()),
+	}
+	for _, bound := range []struct {
+		value  types.String
+		name   string
+		target *time.Time
+	}{
+		{state.CreatedAfter, "created_after", &filter.From},
+		{state.CreatedBefore, "created_before", &filter.To},
+	} {
+		if bound.value.IsNull() {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(bound.name), "Invalid Creation Date Filter", err.Error())
+			return
+		}
+		*bound.target = t
+	}
+
+	orders, err := d.client.GetOrders(filter)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read HashiCups Orders",
+			err.Error(),
+		)
+		return
+	}
+
+	// Map response body to model
+	state.Orders = []ordersModel{}
+	for _, order := range orders {
+		total, count := orderTotals(order.Items)
+		orderState := ordersModel{
+			ID:         types.StringValue(strconv.Itoa(order.ID)),
+			Items:      []orderItemModel{},
+			TotalPrice: types.Float64Value(total.Float64()),
+			ItemCount:  types.Int64Value(count),
+		}
+
+		for _, item := range order.Items {
+			orderState.Items = append(orderState.Items, newOrderItemModel(item))
+		}
+
+		state.Orders = append(state.Orders, orderState)
+	}
+	state.ID = types.StringValue(ordersFilterID(filter))
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ordersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hashicups.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *hashicups.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// ordersFilterID returns the data source id for filter, which differs
+// between filters. Timestamps are compared as instants, so the same range in
+// another time zone has the same id.
+func ordersFilterID(filter hashicups.OrderFilter) string {
+	timestamp := func(t time.Time) string {
+		if t.IsZero() {
+			return "null"
+		}
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+
+	inputs := strings.Join([]string{
+		"coffee_id=" + strconv.Itoa(filter.CoffeeID),
+		"created_after=" + timestamp(filter.From),
+		"created_before=" + timestamp(filter.To),
+	}, "\n")
+	sum := sha256.Sum256([]byte(inputs))
+	return hex.EncodeToString(sum[:8])
+}
+
+// orderItemDataSourceAttributes returns the data source schema attributes
+// of orderItemModel, all computed.
+func orderItemDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"quantity": schema.Int64Attribute{
+			Description: "Count of this item in the order.",
+			Computed:    true,
+		},
+		"subtotal": schema.Float64Attribute{
+			Description: "Price of the coffee times the quantity.",
+			Computed:    true,
+		},
+		"coffee": schema.SingleNestedAttribute{
+			Description: "Coffee item in the order.",
+			Computed:    true,
+			Attributes:  coffeeDataSourceAttributes(),
+		},
+	}
+}
+
+// orderTotals returns the total price and the number of coffees of the
+// given order items. The total is summed in cents so it is exact.
+func orderTotals(items []hashicups.OrderItem) (hashicups.Money, int64) {
+	var total hashicups.Money
+	var count int64
+	for _, item := range items {
+		total = total.Add(item.Coffee.Price.Mul(int64(item.Quantity)))
+		count += int64(item.Quantity)
+	}
+	return total, count
+}
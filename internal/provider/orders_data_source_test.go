@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp-demoapp/hashicups-client-go/hashicupstest"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestOrderTotals(t *testing.T) {
+	total, count := orderTotals([]hashicups.OrderItem{
+		{Coffee: hashicups.Coffee{Price: hashicups.MoneyFromCents(10)}, Quantity: 1},
+		{Coffee: hashicups.Coffee{Price: hashicups.MoneyFromCents(20)}, Quantity: 1},
+		{Coffee: hashicups.Coffee{Price: hashicups.MoneyFromCents(350)}, Quantity: 3},
+	})
+
+	if total.String() != "10.80" {
+		t.Errorf("expected total 10.80, got %s", total)
+	}
+	if count != 5 {
+		t.Errorf("expected 5 coffees, got %d", count)
+	}
+
+	// Summed as float64 this would be 0.30000000000000004.
+	total, _ = orderTotals([]hashicups.OrderItem{
+		{Coffee: hashicups.Coffee{Price: hashicups.MoneyFromCents(10)}, Quantity: 1},
+		{Coffee: hashicups.Coffee{Price: hashicups.MoneyFromCents(20)}, Quantity: 1},
+	})
+	if total.Float64() != 0.3 {
+		t.Errorf("expected total 0.3, got %v", total.Float64())
+	}
+}
+
+func TestOrdersDataSourceRead(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateOrder([]hashicups.OrderItem{
+		{Coffee: hashicups.Coffee{ID: 1}, Quantity: 2},
+		{Coffee: hashicups.Coffee{ID: 2}, Quantity: 1},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var state ordersDataSourceModel
+	readDataSource(t, &ordersDataSource{client: client}, ordersDataSourceModel{
+		ID:       types.StringNull(),
+		CoffeeID: types.Int64Value(2),
+	}, &state)
+
+	if len(state.Orders) != 1 {
+		t.Fatalf("expected 1 order, got %d", len(state.Orders))
+	}
+	order := state.Orders[0]
+	if order.TotalPrice.ValueFloat64() != 750 || order.ItemCount.ValueInt64() != 3 {
+		t.Errorf("expected total price 750 for 3 coffees, got %s for %s", order.TotalPrice, order.ItemCount)
+	}
+	if len(order.Items) != 2 || order.Items[0].Subtotal.ValueFloat64() != 400 {
+		t.Errorf("expected a first item subtotal of 400, got %+v", order.Items)
+	}
+}
+
+func TestOrdersDataSourceReadCreatedRange(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, createdAt := range []string{"2025-01-01T09:00:00Z", "2025-02-01T09:00:00Z", "2025-03-01T09:00:00Z"} {
+		order, err := client.CreateOrder([]hashicups.OrderItem{{Coffee: hashicups.Coffee{ID: i + 1}, Quantity: 1}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		created, _ := time.Parse(time.RFC3339, createdAt)
+		server.SetOrderCreatedAt(order.ID, created)
+	}
+
+	tests := map[string]struct {
+		after, before types.String
+		ids           []string
+	}{
+		"unfiltered":   {after: types.StringNull(), before: types.StringNull(), ids: []string{"1", "2", "3"}},
+		"after":        {after: types.StringValue("2025-02-01T09:00:00Z"), before: types.StringNull(), ids: []string{"2", "3"}},
+		"before":       {after: types.StringNull(), before: types.StringValue("2025-01-31T00:00:00Z"), ids: []string{"1"}},
+		"range":        {after: types.StringValue("2025-01-15T00:00:00+01:00"), before: types.StringValue("2025-02-15T00:00:00Z"), ids: []string{"2"}},
+		"empty window": {after: types.StringValue("2025-04-01T00:00:00Z"), before: types.StringNull(), ids: nil},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var state ordersDataSourceModel
+			readDataSource(t, &ordersDataSource{client: client}, ordersDataSourceModel{
+				ID:            types.StringNull(),
+				CoffeeID:      types.Int64Null(),
+				CreatedAfter:  test.after,
+				CreatedBefore: test.before,
+			}, &state)
+
+			var ids []string
+			for _, order := range state.Orders {
+				ids = append(ids, order.ID.ValueString())
+			}
+			if !slices.Equal(ids, test.ids) {
+				t.Errorf("expected orders %v, got %v", test.ids, ids)
+			}
+		})
+	}
+}
+
+func TestOrdersFilterID(t *testing.T) {
+	parse := func(s string) time.Time {
+		t, _ := time.Parse(time.RFC3339, s)
+		return t
+	}
+
+	filters := map[string]hashicups.OrderFilter{
+		"unfiltered":     {},
+		"coffee":         {CoffeeID: 1},
+		"other coffee":   {CoffeeID: 2},
+		"created after":  {From: parse("2025-01-01T00:00:00Z")},
+		"created before": {To: parse("2025-01-01T00:00:00Z")},
+		"range":          {From: parse("2025-01-01T00:00:00Z"), To: parse("2025-02-01T00:00:00Z")},
+	}
+
+	ids := map[string]string{}
+	for name, filter := range filters {
+		id := ordersFilterID(filter)
+		if other, ok := ids[id]; ok {
+			t.Errorf("filters %q and %q have the same id %s", name, other, id)
+		}
+		ids[id] = name
+	}
+
+	// The same instant in another time zone is the same filter
+	if ordersFilterID(hashicups.OrderFilter{From: parse("2025-01-01T01:00:00+01:00")}) != ordersFilterID(filters["created after"]) {
+		t.Error("expected the same id for the same instant in another time zone")
+	}
+}
+
+func TestOrdersDataSourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	d := NewOrdersDataSource()
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	tests := map[string]struct {
+		after, before types.String
+		valid         bool
+	}{
+		"range":          {after: types.StringValue("2025-01-01T00:00:00Z"), before: types.StringValue("2025-02-01T00:00:00Z"), valid: true},
+		"single instant": {after: types.StringValue("2025-01-01T00:00:00Z"), before: types.StringValue("2025-01-01T01:00:00+01:00"), valid: true},
+		"open range":     {after: types.StringValue("2025-01-01T00:00:00Z"), before: types.StringNull(), valid: true},
+		"unknown":        {after: types.StringUnknown(), before: types.StringValue("2025-01-01T00:00:00Z"), valid: true},
+		"empty":          {after: types.StringValue("2025-02-01T00:00:00Z"), before: types.StringValue("2025-01-01T00:00:00Z")},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := testConfig(t, tfsdk.Config{Schema: schemaResp.Schema}, ordersDataSourceModel{
+				ID:            types.StringNull(),
+				CoffeeID:      types.Int64Null(),
+				CreatedAfter:  test.after,
+				CreatedBefore: test.before,
+			})
+
+			resp := &datasource.ValidateConfigResponse{}
+			d.(datasource.DataSourceWithValidateConfig).ValidateConfig(ctx, datasource.ValidateConfigRequest{Config: config}, resp)
+
+			if resp.Diagnostics.HasError() == test.valid {
+				t.Errorf("expected valid %t, got %v", test.valid, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestAccOrdersDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "hashicups_order" "test" {
+  items = [
+    {
+      coffee = {
+        id = 1
+      }
+      quantity = 2
+    },
+    {
+      coffee = {
+        id = 2
+      }
+      quantity = 1
+    },
+  ]
+}
+
+data "hashicups_orders" "test" {
+  coffee_id = 2
+
+  depends_on = [hashicups_order.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify the order placed above is returned
+					resource.TestCheckTypeSetElemAttrPair("data.hashicups_orders.test", "orders.*.id", "hashicups_order.test", "id"),
+					// Verify items and totals of the order
+					resource.TestCheckTypeSetElemNestedAttrs("data.hashicups_orders.test", "orders.*", map[string]string{
+						"item_count":                   "3",
+						"total_price":                  "750",
+						"items.#":                      "2",
+						"items.0.subtotal":             "400",
+						"items.0.coffee.name":          "HCP Aeropress",
+						"items.0.coffee.collection":    "Foundations",
+						"items.0.coffee.ingredients.#": "1",
+						"items.1.coffee.name":          "Packer Spiced Latte",
+					}),
+					// Verify the id attribute is set
+					resource.TestCheckResourceAttrSet("data.hashicups_orders.test", "id"),
+				),
+			},
+		},
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -38,9 +39,20 @@ type hashicupsProvider struct {
 
 // hashicupsProviderModel maps provider schema data to a Go type.
 type hashicupsProviderModel struct {
-	Host     types.String `tfsdk:"host"`
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
+	Host           types.String `tfsdk:"host"`
+	Username       types.String `tfsdk:"username"`
+	Password       types.String `tfsdk:"password"`
+	MaxConcurrency types.Int64  `tfsdk:"max_concurrency"`
+	MaxOrderTotal  types.Number `tfsdk:"max_order_total"`
+}
+
+// hashicupsResourceData is made available to resources during their
+// Configure methods.
+type hashicupsResourceData struct {
+	client *hashicups.Client
+	// maxOrderTotal is the most any single order may cost, nil when orders
+	// are not limited.
+	maxOrderTotal *hashicups.Money
 }
 
 // Metadata returns the provider type name.
@@ -67,6 +79,17 @@ func (p *hashicupsProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"max_concurrency": schema.Int64Attribute{
+				Description: "Maximum number of HashiCups API requests a single resource operation runs in parallel. Defaults to 4.",
+				Optional:    true,
+			},
+			"max_order_total": schema.NumberAttribute{
+				Description: "Most a single hashicups_order may cost, a positive amount. Plans for orders with a higher total fail. Orders are not limited by default.",
+				Optional:    true,
+				Validators: []validator.Number{
+					money(),
+				},
+			},
 		},
 	}
 }
@@ -111,6 +134,24 @@ func (p *hashicupsProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	if config.MaxConcurrency.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrency"),
+			"Unknown HashiCups API Concurrency",
+			"The provider cannot create the HashiCups API client as there is an unknown configuration value for the HashiCups API concurrency. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.MaxOrderTotal.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_order_total"),
+			"Unknown HashiCups Order Limit",
+			"The provider cannot check orders against an unknown configuration value for the order limit. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -167,6 +208,27 @@ func (p *hashicupsProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	if !config.MaxConcurrency.IsNull() && !config.MaxConcurrency.IsUnknown() && config.MaxConcurrency.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrency"),
+			"Invalid HashiCups API Concurrency",
+			"The provider cannot create the HashiCups API client as max_concurrency must be at least 1.",
+		)
+	}
+
+	var maxOrderTotal *hashicups.Money
+	if !config.MaxOrderTotal.IsNull() {
+		limit, err := moneyFromNumber(config.MaxOrderTotal)
+		if err != nil || limit.Cmp(hashicups.Money{}) <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_order_total"),
+				"Invalid HashiCups Order Limit",
+				"The provider cannot check orders against max_order_total as it must be a positive amount with at most two decimal places.",
+			)
+		}
+		maxOrderTotal = &limit
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -189,17 +251,27 @@ func (p *hashicupsProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
+	if !config.MaxConcurrency.IsNull() && !config.MaxConcurrency.IsUnknown() {
+		client.MaxConcurrency = int(config.MaxConcurrency.ValueInt64())
+	}
+
 	// Make the HashiCups client available during DataSource and Resource
 	// type Configure methods.
 	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.ResourceData = &hashicupsResourceData{
+		client:        client,
+		maxOrderTotal: maxOrderTotal,
+	}
 	tflog.Info(ctx, "Configured HashiCups client", map[string]any{"success": true})
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *hashicupsProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewCoffeeDataSource,
 		NewCoffeesDataSource,
+		NewOrderDataSource,
+		NewOrdersDataSource,
 	}
 }
 
@@ -208,5 +280,6 @@ func (p *hashicupsProvider) Resources(_ context.Context) []func() resource.Resou
 	return []func() resource.Resource{
 		NewOrderResource,
 		NewCoffeeResource,
+		NewOrderItemResource,
 	}
 }
@@ -1,8 +1,21 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp-demoapp/hashicups-client-go"
+	"github.com/hashicorp-demoapp/hashicups-client-go/hashicupstest"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 const (
@@ -28,3 +41,221 @@ var (
 		"hashicups": providerserver.NewProtocol6WithError(New("test")()),
 	}
 )
+
+// testAccProviderConfig returns a provider configuration for a local
+// hashicupstest server, for tests that need to tamper with the API.
+func testAccProviderConfig(s *hashicupstest.Server) string {
+	return fmt.Sprintf(`
+provider "hashicups" {
+  username = %q
+  password = %q
+  host     = %q
+}
+`, hashicupstest.Username, hashicupstest.Password, s.URL)
+}
+
+// testConfig returns config holding the model, encoded with the schema of
+// config. Config has no setter, so the model is encoded through State.
+func testConfig(t *testing.T, config tfsdk.Config, model any) tfsdk.Config {
+	t.Helper()
+	ctx := context.Background()
+
+	state := tfsdk.State{Schema: config.Schema, Raw: tftypes.NewValue(config.Schema.Type().TerraformType(ctx), nil)}
+	if diags := state.Set(ctx, model); diags.HasError() {
+		t.Fatal(diags)
+	}
+	config.Raw = state.Raw
+	return config
+}
+
+// readDataSource reads the data source with the configuration encoded from
+// config and decodes the resulting state into target.
+func readDataSource(t *testing.T, d datasource.DataSource, config, target any) {
+	t.Helper()
+
+	resp := readDataSourceResponse(t, d, config)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if diags := resp.State.Get(context.Background(), target); diags.HasError() {
+		t.Fatal(diags)
+	}
+}
+
+// readDataSourceResponse reads the data source with the configuration encoded
+// from config and returns the response, diagnostics included.
+func readDataSourceResponse(t *testing.T, d datasource.DataSource, config any) *datasource.ReadResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	null := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: null}}
+	d.Read(ctx, datasource.ReadRequest{Config: testConfig(t, tfsdk.Config{Schema: schemaResp.Schema}, config)}, resp)
+	return resp
+}
+
+// upgradeResourceState upgrades raw state JSON of the given resource type and
+// schema version through the provider server and decodes it into target.
+func upgradeResourceState(t *testing.T, r resource.Resource, typeName string, version int64, state string, target any) {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: typeName,
+		Version:  version,
+		RawState: &tfprotov6.RawState{JSON: []byte(state)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Errorf("%s: %s", d.Summary, d.Detail)
+	}
+	if t.Failed() {
+		t.FailNow()
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	raw, err := resp.UpgradedState.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags := tfsdk.State{Schema: schemaResp.Schema, Raw: raw}.Get(ctx, target)
+	if diags.HasError() {
+		t.Fatalf("decoding upgraded state: %v", diags)
+	}
+}
+
+// assertResourceModel compares models of the resource by their Terraform
+// values.
+func assertResourceModel(t *testing.T, r resource.Resource, got, expected any) {
+	t.Helper()
+
+	gotValue, expectedValue := resourceModelValue(t, r, got), resourceModelValue(t, r, expected)
+	if !gotValue.Equal(expectedValue) {
+		diffs, _ := expectedValue.Diff(gotValue)
+		for _, diff := range diffs {
+			t.Errorf("%s: expected %s, got %s", diff.Path, diff.Value1, diff.Value2)
+		}
+	}
+}
+
+// resourceModelValue encodes the model with the current schema of the
+// resource.
+func resourceModelValue(t *testing.T, r resource.Resource, model any) tftypes.Value {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, model); diags.HasError() {
+		t.Fatalf("encoding model: %v", diags)
+	}
+	return state.Raw
+}
+
+func TestProviderConfigureMaxOrderTotal(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	p := New("test")()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	tests := map[string]struct {
+		limit types.Number
+		valid bool
+	}{
+		"unset":            {limit: types.NumberNull(), valid: true},
+		"positive":         {limit: types.NumberValue(big.NewFloat(1000)), valid: true},
+		"zero":             {limit: types.NumberValue(big.NewFloat(0))},
+		"negative":         {limit: types.NumberValue(big.NewFloat(-5))},
+		"fraction of cent": {limit: types.NumberValue(big.NewFloat(0.001))},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := testConfig(t, tfsdk.Config{Schema: schemaResp.Schema}, hashicupsProviderModel{
+				Host:           types.StringValue(server.URL),
+				Username:       types.StringValue(hashicupstest.Username),
+				Password:       types.StringValue(hashicupstest.Password),
+				MaxConcurrency: types.Int64Null(),
+				MaxOrderTotal:  test.limit,
+			})
+
+			resp := &provider.ConfigureResponse{}
+			p.Configure(ctx, provider.ConfigureRequest{Config: config}, resp)
+
+			if resp.Diagnostics.HasError() == test.valid {
+				t.Errorf("expected valid %t, got %v", test.valid, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureMaxConcurrency(t *testing.T) {
+	server := hashicupstest.NewServer()
+	defer server.Close()
+
+	ctx := context.Background()
+	p := New("test")()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+
+	tests := map[string]struct {
+		concurrency types.Int64
+		expected    int
+		summary     string
+	}{
+		"unset":   {concurrency: types.Int64Null(), expected: 4},
+		"set":     {concurrency: types.Int64Value(2), expected: 2},
+		"zero":    {concurrency: types.Int64Value(0), summary: "Invalid HashiCups API Concurrency"},
+		"unknown": {concurrency: types.Int64Unknown(), summary: "Unknown HashiCups API Concurrency"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := testConfig(t, tfsdk.Config{Schema: schemaResp.Schema}, hashicupsProviderModel{
+				Host:           types.StringValue(server.URL),
+				Username:       types.StringValue(hashicupstest.Username),
+				Password:       types.StringValue(hashicupstest.Password),
+				MaxConcurrency: test.concurrency,
+				MaxOrderTotal:  types.NumberNull(),
+			})
+
+			resp := &provider.ConfigureResponse{}
+			p.Configure(ctx, provider.ConfigureRequest{Config: config}, resp)
+
+			errs := resp.Diagnostics.Errors()
+			if test.summary != "" {
+				if len(errs) != 1 || errs[0].Summary() != test.summary {
+					t.Errorf("expected a %q error, got %v", test.summary, resp.Diagnostics)
+				}
+				return
+			}
+			if len(errs) != 0 {
+				t.Fatalf("expected no errors, got %v", errs)
+			}
+			if client := resp.DataSourceData.(*hashicups.Client); client.MaxConcurrency != test.expected {
+				t.Errorf("expected max concurrency %d, got %d", test.expected, client.MaxConcurrency)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"maps"
+	"math"
+	"slices"
+)
+
+// Unit dimensions. Quantities can only be compared within a dimension.
+const (
+	unitDimensionVolume = "volume"
+	unitDimensionMass   = "mass"
+	unitDimensionCount  = "count"
+)
+
+// unit describes an ingredient unit of measurement.
+type unit struct {
+	dimension string
+	// base is the unit this unit converts to. Count units are their own base,
+	// a shot and a pump cannot be compared.
+	base string
+	// factor converts a quantity in this unit to the base unit.
+	factor float64
+}
+
+// units is the registry of ingredient units accepted by hashicups_coffee.
+var units = map[string]unit{
+	"ml":   {dimension: unitDimensionVolume, base: "ml", factor: 1},
+	"cl":   {dimension: unitDimensionVolume, base: "ml", factor: 10},
+	"dl":   {dimension: unitDimensionVolume, base: "ml", factor: 100},
+	"l":    {dimension: unitDimensionVolume, base: "ml", factor: 1000},
+	"tsp":  {dimension: unitDimensionVolume, base: "ml", factor: 5},
+	"tbsp": {dimension: unitDimensionVolume, base: "ml", factor: 15},
+	"floz": {dimension: unitDimensionVolume, base: "ml", factor: 29.5735},
+	"cup":  {dimension: unitDimensionVolume, base: "ml", factor: 240},
+
+	"mg": {dimension: unitDimensionMass, base: "g", factor: 0.001},
+	"g":  {dimension: unitDimensionMass, base: "g", factor: 1},
+	"kg": {dimension: unitDimensionMass, base: "g", factor: 1000},
+	"oz": {dimension: unitDimensionMass, base: "g", factor: 28.349523125},
+
+	"piece": {dimension: unitDimensionCount, base: "piece", factor: 1},
+	"shot":  {dimension: unitDimensionCount, base: "shot", factor: 1},
+	"pump":  {dimension: unitDimensionCount, base: "pump", factor: 1},
+	"scoop": {dimension: unitDimensionCount, base: "scoop", factor: 1},
+	"cube":  {dimension: unitDimensionCount, base: "cube", factor: 1},
+}
+
+// unitNames returns the names of all known units in sorted order.
+func unitNames() []string {
+	return slices.Sorted(maps.Keys(units))
+}
+
+// sameQuantity reports whether two quantities describe the same amount, such
+// as 10 dl and 1000 ml. Unknown units only match themselves.
+func sameQuantity(quantityA float64, unitA string, quantityB float64, unitB string) bool {
+	if unitA == unitB {
+		return quantityA == quantityB
+	}
+
+	a, okA := units[unitA]
+	b, okB := units[unitB]
+	if !okA || !okB || a.base != b.base {
+		return false
+	}
+
+	// Allow for rounding in the conversion factors.
+	normalizedA, normalizedB := quantityA*a.factor, quantityB*b.factor
+	return math.Abs(normalizedA-normalizedB) <= 1e-9*math.Max(math.Abs(normalizedA), math.Abs(normalizedB))
+}
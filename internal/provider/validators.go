@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ validator.String = stringOneOfValidator{}
+	_ validator.String = stringMatchesValidator{}
+	_ validator.String = regexValidator{}
+	_ validator.String = timestampValidator{}
+	_ validator.Number = moneyValidator{}
+)
+
+// stringOneOfValidator checks that a string attribute is one of a fixed set
+// of values.
+type stringOneOfValidator struct {
+	values []string
+}
+
+// stringOneOf returns a validator which ensures the attribute value is one of
+// values.
+func stringOneOf(values ...string) validator.String {
+	return stringOneOfValidator{values: values}
+}
+
+func (v stringOneOfValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be one of: %q", v.values)
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !slices.Contains(v.values, req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s must be one of %s, got: %q", req.Path, strings.Join(v.values, ", "), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// hexColorPattern matches three or six digit CSS hex color codes.
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// currencyCodePattern matches ISO 4217 style currency codes.
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// stringMatchesValidator checks that a string attribute matches a regular
+// expression.
+type stringMatchesValidator struct {
+	pattern *regexp.Regexp
+	format  string
+}
+
+// stringMatches returns a validator which ensures the attribute value matches
+// pattern. format describes the expected values to the practitioner.
+func stringMatches(pattern *regexp.Regexp, format string) validator.String {
+	return stringMatchesValidator{pattern: pattern, format: format}
+}
+
+// hexColor returns a validator which ensures the attribute value is a hex
+// color code such as #444 or #0F3B5C.
+func hexColor() validator.String {
+	return stringMatches(hexColorPattern, "a hex color code such as #444 or #0F3B5C")
+}
+
+// currencyCode returns a validator which ensures the attribute value is a
+// three letter currency code such as USD.
+func currencyCode() validator.String {
+	return stringMatches(currencyCodePattern, "a three letter currency code such as USD")
+}
+
+func (v stringMatchesValidator) Description(_ context.Context) string {
+	return "value must be " + v.format
+}
+
+func (v stringMatchesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringMatchesValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !v.pattern.MatchString(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s must be %s, got: %q", req.Path, v.format, req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// regexValidator checks that a string attribute is a valid regular
+// expression.
+type regexValidator struct{}
+
+// validRegex returns a validator which ensures the attribute value is a
+// regular expression in the Go RE2 syntax.
+func validRegex() validator.String {
+	return regexValidator{}
+}
+
+func (v regexValidator) Description(_ context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v regexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s must be a valid regular expression: %s", req.Path, err),
+		)
+	}
+}
+
+// timestampValidator checks that a string attribute is an RFC 3339
+// timestamp.
+type timestampValidator struct{}
+
+// rfc3339 returns a validator which ensures the attribute value is an RFC 3339
+// timestamp such as 2025-01-02T15:04:05Z.
+func rfc3339() validator.String {
+	return timestampValidator{}
+}
+
+func (v timestampValidator) Description(_ context.Context) string {
+	return "value must be an RFC 3339 timestamp"
+}
+
+func (v timestampValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timestampValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s must be an RFC 3339 timestamp such as 2025-01-02T15:04:05Z, got: %q", req.Path, req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// moneyValidator checks that a number attribute is an amount of money with
+// at most two decimal places.
+type moneyValidator struct{}
+
+// money returns a validator which ensures the attribute value is an amount of
+// money with at most two decimal places.
+func money() validator.Number {
+	return moneyValidator{}
+}
+
+func (v moneyValidator) Description(_ context.Context) string {
+	return "value must have at most two decimal places"
+}
+
+func (v moneyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v moneyValidator) ValidateNumber(ctx context.Context, req validator.NumberRequest, resp *validator.NumberResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := moneyFromNumber(req.ConfigValue); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s must be an amount with at most two decimal places: %s", req.Path, err),
+		)
+	}
+}